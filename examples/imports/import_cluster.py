@@ -475,7 +475,7 @@ def getQuotaBlock(quota) -> str:
 def getLocalGroupBlock(group) -> str:
     gid = ''
     if group["gid"]:
-        gid = f'\n  gid = "{group["gid"]}"'
+        gid = f'\n  gid = {group["gid"]}'
 
     return f"""resource "qumulo_local_group" "{group["name"]}" {{
   name = "{group["name"]}"{gid}
@@ -494,7 +494,7 @@ def getLocalGroupMembersBlock(group, ids) -> str:
 def getLocalUserBlock(user) -> str:
     uid = ''
     if user["uid"]:
-        uid = f'\n  uid = "{user["uid"]}"'
+        uid = f'\n  uid = {user["uid"]}'
 
     home_directory = ''
     if user["home_directory"]:
@@ -65,8 +65,17 @@ resource "qumulo_monitoring" "update_monitoring" {
   period = 60
 }
 
+# Checking that the cluster clock is synced before joining an AD domain
+data "qumulo_time_status" "time" {}
+
 # Configuring the Active Directory settings and joining to an AD domain
 resource "qumulo_ad_settings" "ad_settings" {
+  lifecycle {
+    precondition {
+      condition     = data.qumulo_time_status.time.synced && abs(data.qumulo_time_status.time.local_skew_seconds) < 300
+      error_message = "The cluster clock must be synced before joining Active Directory."
+    }
+  }
   signing = "WANT_SIGNING"
   sealing = "WANT_SEALING"
   crypto = "WANT_AES"
@@ -78,6 +87,15 @@ resource "qumulo_ad_settings" "ad_settings" {
   base_dn = "CN=Users,DC=ad,DC=eng,DC=qumulo,DC=com"
 }
 
+# Reading the Active Directory join status, e.g. to gate share creation on a healthy join
+data "qumulo_ad_status" "ad_status" {
+  depends_on = [qumulo_ad_settings.ad_settings]
+}
+
+output "ad_domain_controllers" {
+  value = data.qumulo_ad_status.ad_status.dcs
+}
+
 # Configuring NFS settings
 resource "qumulo_nfs_settings" "my_new_settings" {
   v4_enabled = false
@@ -220,7 +238,14 @@ resource "qumulo_network_configuration" "network_config" {
 # Setting a directory quota for the directory with ID 2
 resource "qumulo_directory_quota" "new_quota" {
     directory_id = "2"
-    limit = "1000000000"
+    limit = "1GB"
+}
+
+# Reading the usage of every directory quota on the cluster
+data "qumulo_directory_quotas" "all" {}
+
+output "nearly_full_quotas" {
+  value = [for q in data.qumulo_directory_quotas.all.quotas : q.path if q.percent_used > 90]
 }
 
 # Creating 3 local users with the same group, password, and home directory
@@ -279,9 +304,7 @@ resource "qumulo_file_system_settings" "fs_settings" {
 
 # Configuring the web UI settings
 resource "qumulo_web_ui" "settings" {
-  inactivity_timeout {
-    nanoseconds = 900000000001
-  }
+  inactivity_timeout = "15m"
   login_banner = "SampleBanner"
 }
 
@@ -290,6 +313,8 @@ resource "qumulo_syslog" "syslog_audit_log" {
   enabled = false
   server_address = ""
   server_port = 0
+  # Put back whatever was configured before this resource was created on terraform destroy
+  on_destroy = "restore_previous"
 }
 resource "qumulo_cloudwatch" "cloudwatch_audit_log" {
   enabled = false
@@ -308,3 +333,13 @@ resource "qumulo_ftp_server" "some_ftp_server" {
   greeting = "Hello!"
 }
 
+
+# Failing the plan when a node is offline or a disk has failed
+data "qumulo_nodes" "nodes" {
+  lifecycle {
+    postcondition {
+      condition     = !self.degraded
+      error_message = "The cluster is degraded; check node and disk health before applying changes."
+    }
+  }
+}
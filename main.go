@@ -1,19 +1,38 @@
 package main
 
 import (
+	"context"
+	"log"
+	"os"
+
 	"terraform-provider-qumulo/qumulo"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/plugin"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6/tf6server"
 )
 
 // Generate the Terraform provider documentation using `tfplugindocs`:
 //go:generate go run github.com/hashicorp/terraform-plugin-docs/cmd/tfplugindocs
 
 func main() {
-	plugin.Serve(&plugin.ServeOpts{
-		ProviderFunc: func() *schema.Provider {
-			return qumulo.Provider()
-		},
-	})
+	// `terraform-provider-qumulo export` generates configuration for an existing cluster, and
+	// `terraform-provider-qumulo drift` compares a cluster to its configuration. Terraform starts the
+	// provider without arguments.
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "export":
+			os.Exit(qumulo.RunExport(context.Background(), os.Args[2:], os.Stdout, os.Stderr))
+		case "drift":
+			os.Exit(qumulo.RunDrift(context.Background(), os.Args[2:], os.Stdout, os.Stderr))
+		}
+	}
+
+	ctx := context.Background()
+	providerServer, err := qumulo.ProviderServer(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := tf6server.Serve("registry.terraform.io/Qumulo/qumulo", providerServer); err != nil {
+		log.Fatal(err)
+	}
 }
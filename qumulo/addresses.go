@@ -0,0 +1,231 @@
+package qumulo
+
+import (
+	"fmt"
+	"net/netip"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// Qumulo accepts single addresses ("10.0.0.1"), CIDR blocks ("10.0.0.0/24"), full ranges
+// ("10.0.0.1-10.0.0.20") and ranges which only repeat the last octet or IPv6 group ("10.0.0.1-20",
+// "fd00::1-ff") wherever a list of addresses is expected.
+type IpRange struct {
+	First netip.Addr
+	Last  netip.Addr
+}
+
+var hostnameRegexp = regexp.MustCompile(`^(?i)[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?(\.[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?)*\.?$`)
+
+// A top-level label of only digits and hyphens is a mistyped address or range rather than a hostname
+var numericLabelRegexp = regexp.MustCompile(`^[0-9-]+$`)
+
+func parseIpRange(v string) (IpRange, error) {
+	if strings.Contains(v, "/") {
+		prefix, err := netip.ParsePrefix(v)
+		if err != nil {
+			return IpRange{}, fmt.Errorf("%q is not a valid CIDR block", v)
+		}
+
+		prefix = prefix.Masked()
+		return IpRange{First: prefix.Addr(), Last: lastAddrInPrefix(prefix)}, nil
+	}
+
+	first, last, isRange := strings.Cut(v, "-")
+
+	firstAddr, err := netip.ParseAddr(first)
+	if err != nil {
+		return IpRange{}, fmt.Errorf("%q is not a valid IP address", first)
+	}
+	if !isRange {
+		return IpRange{First: firstAddr, Last: firstAddr}, nil
+	}
+
+	lastAddr, err := parseRangeEnd(firstAddr, last)
+	if err != nil {
+		return IpRange{}, fmt.Errorf("%q is not a valid IP range: %w", v, err)
+	}
+	if lastAddr.Less(firstAddr) {
+		return IpRange{}, fmt.Errorf("%q is not a valid IP range: the end of the range comes before the start", v)
+	}
+
+	return IpRange{First: firstAddr, Last: lastAddr}, nil
+}
+
+// The end of a range is either a full address of the same family, or the last octet of an IPv4
+// address or the last group of an IPv6 address
+func parseRangeEnd(first netip.Addr, v string) (netip.Addr, error) {
+	if addr, err := netip.ParseAddr(v); err == nil {
+		if addr.Is4() != first.Is4() {
+			return netip.Addr{}, fmt.Errorf("the start and end of the range are different address families")
+		}
+		return addr, nil
+	}
+
+	if first.Is4() {
+		octet, err := strconv.ParseUint(v, 10, 8)
+		if err != nil {
+			return netip.Addr{}, fmt.Errorf("%q is not an IPv4 address or octet", v)
+		}
+
+		b := first.As4()
+		b[3] = byte(octet)
+		return netip.AddrFrom4(b), nil
+	}
+
+	group, err := strconv.ParseUint(v, 16, 16)
+	if err != nil {
+		return netip.Addr{}, fmt.Errorf("%q is not an IPv6 address or group", v)
+	}
+
+	b := first.As16()
+	b[14] = byte(group >> 8)
+	b[15] = byte(group)
+	return netip.AddrFrom16(b).WithZone(first.Zone()), nil
+}
+
+func lastAddrInPrefix(prefix netip.Prefix) netip.Addr {
+	addr := prefix.Addr()
+	b := addr.AsSlice()
+	for bit := prefix.Bits(); bit < len(b)*8; bit++ {
+		b[bit/8] |= 1 << (7 - bit%8)
+	}
+
+	last, _ := netip.AddrFromSlice(b)
+	return last
+}
+
+func (r IpRange) Overlaps(other IpRange) bool {
+	if r.First.Is4() != other.First.Is4() {
+		return false
+	}
+
+	return !r.Last.Less(other.First) && !other.Last.Less(r.First)
+}
+
+// Finds the first pair of overlapping ranges, e.g. a floating IP which is also handed out statically
+func findOverlappingIpRanges(ranges []string) (string, string, bool) {
+	parsed := make([]IpRange, len(ranges))
+	for i, v := range ranges {
+		r, err := parseIpRange(v)
+		if err != nil {
+			// Malformed ranges are reported by the attribute validation
+			continue
+		}
+		parsed[i] = r
+	}
+
+	for i := range parsed {
+		for j := i + 1; j < len(parsed); j++ {
+			if parsed[i].First.IsValid() && parsed[j].First.IsValid() && parsed[i].Overlaps(parsed[j]) {
+				return ranges[i], ranges[j], true
+			}
+		}
+	}
+
+	return "", "", false
+}
+
+func validateIpRange(i interface{}, k string) ([]string, []error) {
+	v, ok := i.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %s to be string", k)}
+	}
+
+	if _, err := parseIpRange(v); err != nil {
+		return nil, []error{fmt.Errorf("expected %s to be an IP address, CIDR block or IP range: %w", k, err)}
+	}
+
+	return nil, nil
+}
+
+func validateHostname(i interface{}, k string) ([]string, []error) {
+	v, ok := i.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %s to be string", k)}
+	}
+
+	labels := strings.Split(strings.TrimSuffix(v, "."), ".")
+	if len(v) > 253 || !hostnameRegexp.MatchString(v) || numericLabelRegexp.MatchString(labels[len(labels)-1]) {
+		return nil, []error{fmt.Errorf("expected %s to be a valid hostname, got %q", k, v)}
+	}
+
+	return nil, nil
+}
+
+func validateHostOrIpAddress(i interface{}, k string) ([]string, []error) {
+	if _, errs := validation.IsIPAddress(i, k); errs == nil {
+		return nil, nil
+	}
+
+	return validateHostname(i, k)
+}
+
+// NFS host restrictions additionally allow client hostnames
+func validateHostRestriction(i interface{}, k string) ([]string, []error) {
+	if _, errs := validateIpRange(i, k); errs == nil {
+		return nil, nil
+	}
+
+	if _, errs := validateHostname(i, k); errs == nil {
+		return nil, nil
+	}
+
+	return nil, []error{fmt.Errorf("expected %s to be an IP address, CIDR block, IP range or hostname, got %q", k, i)}
+}
+
+// A netmask is either a dotted IPv4 mask or, for IPv6 networks, a prefix length
+func validateNetmask(i interface{}, k string) ([]string, []error) {
+	v, ok := i.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %s to be string", k)}
+	}
+
+	if bits, err := strconv.Atoi(v); err == nil && bits >= 0 && bits <= 128 {
+		return nil, nil
+	}
+
+	addr, err := netip.ParseAddr(v)
+	if err != nil {
+		return nil, []error{fmt.Errorf("expected %s to be a netmask or prefix length, got %q", k, v)}
+	}
+
+	// The mask has to be a run of ones followed by a run of zeroes
+	b := addr.AsSlice()
+	seenZero := false
+	for bit := 0; bit < len(b)*8; bit++ {
+		set := b[bit/8]&(1<<(7-bit%8)) != 0
+		if set && seenZero {
+			return nil, []error{fmt.Errorf("expected %s to be a contiguous netmask, got %q", k, v)}
+		}
+		seenZero = seenZero || !set
+	}
+
+	return nil, nil
+}
+
+func ipAddressListSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		Elem: &schema.Schema{
+			Type:             schema.TypeString,
+			ValidateDiagFunc: validation.ToDiagFunc(validation.IsIPAddress),
+		},
+	}
+}
+
+func ipRangeListSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		Elem: &schema.Schema{
+			Type:             schema.TypeString,
+			ValidateDiagFunc: validation.ToDiagFunc(validateIpRange),
+		},
+	}
+}
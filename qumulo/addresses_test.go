@@ -0,0 +1,77 @@
+package qumulo
+
+import (
+	"testing"
+)
+
+func TestParseIpRange(t *testing.T) {
+	cases := []struct {
+		in          string
+		first, last string
+	}{
+		{"10.0.0.1", "10.0.0.1", "10.0.0.1"},
+		{"10.0.0.1-20", "10.0.0.1", "10.0.0.20"},
+		{"10.0.0.1-10.0.1.5", "10.0.0.1", "10.0.1.5"},
+		{"10.0.0.17/28", "10.0.0.16", "10.0.0.31"},
+		{"fd00::1", "fd00::1", "fd00::1"},
+		{"fd00::1-ff", "fd00::1", "fd00::ff"},
+		{"fd00::/120", "fd00::", "fd00::ff"},
+	}
+
+	for _, tc := range cases {
+		r, err := parseIpRange(tc.in)
+		if err != nil {
+			t.Errorf("unexpected error parsing %q: %v", tc.in, err)
+			continue
+		}
+		if r.First.String() != tc.first || r.Last.String() != tc.last {
+			t.Errorf("expected %q to span %s-%s, got %s-%s", tc.in, tc.first, tc.last, r.First, r.Last)
+		}
+	}
+
+	for _, in := range []string{"", "10.0.0", "10.0.0.20-1", "10.0.0.1-256", "10.0.0.1-fd00::2", "10.0.0.0/33", "host.example.com"} {
+		if _, err := parseIpRange(in); err == nil {
+			t.Errorf("expected an error parsing %q", in)
+		}
+	}
+}
+
+func TestFindOverlappingIpRanges(t *testing.T) {
+	if first, second, ok := findOverlappingIpRanges([]string{"10.0.0.1-10", "10.0.0.11-20", "10.0.0.15"}); !ok || first != "10.0.0.11-20" || second != "10.0.0.15" {
+		t.Errorf("expected 10.0.0.11-20 and 10.0.0.15 to overlap, got %q %q %v", first, second, ok)
+	}
+	if _, _, ok := findOverlappingIpRanges([]string{"10.0.0.0/28", "10.0.0.16-31", "fd00::/8"}); ok {
+		t.Errorf("expected adjacent ranges and different address families not to overlap")
+	}
+}
+
+func TestValidateAddresses(t *testing.T) {
+	valid := map[string][]string{
+		"host_restriction": {"10.100.38.31", "10.100.38.0/24", "10.100.38.1-9", "client-1.example.com"},
+		"netmask":          {"255.255.255.0", "64", "ffff:ffff:ffff:ffff::"},
+		"host":             {"0.qumulo.pool.ntp.org", "127.0.0.1", "fd00::1"},
+	}
+	invalid := map[string][]string{
+		"host_restriction": {"10.100.38.9-1", "-client.example.com", "10.100.38.0/40"},
+		"netmask":          {"255.0.255.0", "129", "mask"},
+		"host":             {"ntp_server", "a..b"},
+	}
+	validators := map[string]func(interface{}, string) ([]string, []error){
+		"host_restriction": validateHostRestriction,
+		"netmask":          validateNetmask,
+		"host":             validateHostOrIpAddress,
+	}
+
+	for name, validate := range validators {
+		for _, v := range valid[name] {
+			if _, errs := validate(v, name); errs != nil {
+				t.Errorf("expected %q to be a valid %s, got %v", v, name, errs)
+			}
+		}
+		for _, v := range invalid[name] {
+			if _, errs := validate(v, name); errs == nil {
+				t.Errorf("expected %q not to be a valid %s", v, name)
+			}
+		}
+	}
+}
@@ -0,0 +1,279 @@
+package qumulo
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+type CassetteMode int
+
+const (
+	CassetteRecord CassetteMode = iota + 1
+	CassetteReplay
+)
+
+func (m CassetteMode) String() string {
+	return [...]string{"record", "replay"}[m-1]
+}
+
+const CassetteRedacted = "REDACTED"
+
+// Credentials in these fields are replaced in request and response bodies before they are written to a
+// cassette. The Authorization header is never recorded.
+var cassetteRedactedFields = map[string]bool{
+	"password":     true,
+	"bearer_token": true,
+	"private_key":  true,
+	"username":     true,
+}
+
+// Response headers the provider relies on; the others are left out of cassettes
+var cassetteResponseHeaders = []string{"Content-Type", "ETag"}
+
+type Cassette struct {
+	Interactions []CassetteInteraction `yaml:"interactions"`
+}
+
+type CassetteInteraction struct {
+	Request  CassetteRequest  `yaml:"request"`
+	Response CassetteResponse `yaml:"response"`
+}
+
+type CassetteRequest struct {
+	Method string `yaml:"method"`
+	Uri    string `yaml:"uri"`
+	Body   string `yaml:"body,omitempty"`
+}
+
+type CassetteResponse struct {
+	Status  int               `yaml:"status"`
+	Headers map[string]string `yaml:"headers,omitempty"`
+	Body    string            `yaml:"body,omitempty"`
+}
+
+// An http.RoundTripper which records the interactions with a cluster to a YAML cassette, or replays
+// them from one without a cluster.
+//
+// Terraform creates and reads independent resources concurrently, so requests aren't replayed in a
+// fixed order: each request is answered by the first unused interaction with the same method and URI.
+// In strict mode the request bodies have to match too, and Unused reports the recorded interactions
+// which were never replayed.
+type CassetteTransport struct {
+	Path   string
+	Mode   CassetteMode
+	Strict bool
+
+	// Sends the requests being recorded
+	Transport http.RoundTripper
+
+	mu       sync.Mutex
+	cassette Cassette
+	used     []bool
+}
+
+func NewCassetteTransport(path string, mode CassetteMode, strict bool, transport http.RoundTripper) (*CassetteTransport, error) {
+	t := &CassetteTransport{
+		Path:      path,
+		Mode:      mode,
+		Strict:    strict,
+		Transport: transport,
+	}
+
+	if mode == CassetteReplay {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("reading cassette: %w", err)
+		}
+		if err := yaml.Unmarshal(b, &t.cassette); err != nil {
+			return nil, fmt.Errorf("parsing cassette %s: %w", path, err)
+		}
+		t.used = make([]bool, len(t.cassette.Interactions))
+	}
+
+	return t, nil
+}
+
+func (t *CassetteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	recorded := CassetteRequest{
+		Method: req.Method,
+		Uri:    req.URL.RequestURI(),
+		Body:   redactCassetteBody(body),
+	}
+
+	if t.Mode == CassetteRecord {
+		return t.record(req, recorded)
+	}
+	return t.replay(req, recorded)
+}
+
+func (t *CassetteTransport) record(req *http.Request, recorded CassetteRequest) (*http.Response, error) {
+	res, err := t.Transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := io.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	res.Body = io.NopCloser(bytes.NewReader(body))
+
+	headers := map[string]string{}
+	for _, h := range cassetteResponseHeaders {
+		if v := res.Header.Get(h); v != "" {
+			headers[h] = v
+		}
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.cassette.Interactions = append(t.cassette.Interactions, CassetteInteraction{
+		Request: recorded,
+		Response: CassetteResponse{
+			Status:  res.StatusCode,
+			Headers: headers,
+			Body:    redactCassetteBody(body),
+		},
+	})
+
+	return res, nil
+}
+
+func (t *CassetteTransport) replay(req *http.Request, recorded CassetteRequest) (*http.Response, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for i, interaction := range t.cassette.Interactions {
+		if t.used[i] || interaction.Request.Method != recorded.Method || interaction.Request.Uri != recorded.Uri {
+			continue
+		}
+		if t.Strict && interaction.Request.Body != recorded.Body {
+			continue
+		}
+		t.used[i] = true
+
+		res := &http.Response{
+			Status:        fmt.Sprintf("%d %s", interaction.Response.Status, http.StatusText(interaction.Response.Status)),
+			StatusCode:    interaction.Response.Status,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        http.Header{},
+			Body:          io.NopCloser(strings.NewReader(interaction.Response.Body)),
+			ContentLength: int64(len(interaction.Response.Body)),
+			Request:       req,
+		}
+		for k, v := range interaction.Response.Headers {
+			res.Header.Set(k, v)
+		}
+
+		return res, nil
+	}
+
+	if t.Strict {
+		return nil, fmt.Errorf("cassette %s has no unused interaction matching %s %s with body %s; re-record it if the "+
+			"requests changed", t.Path, recorded.Method, recorded.Uri, recorded.Body)
+	}
+	return nil, fmt.Errorf("cassette %s has no unused interaction matching %s %s; re-record it if the requests changed",
+		t.Path, recorded.Method, recorded.Uri)
+}
+
+// The requests which were recorded but not replayed
+func (t *CassetteTransport) Unused() []CassetteRequest {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var unused []CassetteRequest
+	for i, interaction := range t.cassette.Interactions {
+		if !t.used[i] {
+			unused = append(unused, interaction.Request)
+		}
+	}
+
+	return unused
+}
+
+// Writes the recorded interactions to the cassette
+func (t *CassetteTransport) Save() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.Mode != CassetteRecord {
+		return fmt.Errorf("cassette %s was opened for %s, not %s", t.Path, t.Mode, CassetteRecord)
+	}
+
+	b, err := yaml.Marshal(t.cassette)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(t.Path), 0755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(t.Path, b, 0644)
+}
+
+// Replaces secrets in a JSON body. Bodies are re-encoded with sorted keys, so that the same request
+// always produces the same cassette body.
+func redactCassetteBody(body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+
+	var v interface{}
+	if err := decoder.Decode(&v); err != nil {
+		return string(body)
+	}
+
+	redacted, err := json.Marshal(redactCassetteValue(v))
+	if err != nil {
+		return string(body)
+	}
+
+	return string(redacted)
+}
+
+func redactCassetteValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, field := range v {
+			if s, ok := field.(string); ok && s != "" && cassetteRedactedFields[k] {
+				v[k] = CassetteRedacted
+			} else {
+				v[k] = redactCassetteValue(field)
+			}
+		}
+	case []interface{}:
+		for i, element := range v {
+			v[i] = redactCassetteValue(element)
+		}
+	}
+
+	return v
+}
@@ -0,0 +1,184 @@
+package qumulo
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// Acceptance tests record their interactions to, or replay them from, testdata/cassettes/<test name>.yaml
+// when this is set to "record", "replay" or "strict". Cassettes are recorded against the cluster the
+// tests would otherwise use, and replayed without one.
+const CassetteModeEnvVar = "QUMULO_TEST_CASSETTES"
+
+const CassetteDirectory = "testdata/cassettes"
+
+var testAccCassette *CassetteTransport
+
+func testAccCassetteMode(t *testing.T) (CassetteMode, bool) {
+	switch v := os.Getenv(CassetteModeEnvVar); v {
+	case "":
+		return 0, false
+	case "record":
+		return CassetteRecord, false
+	case "replay":
+		return CassetteReplay, false
+	case "strict":
+		return CassetteReplay, true
+	default:
+		t.Fatalf("%s must be one of record, replay or strict, got %q", CassetteModeEnvVar, v)
+		return 0, false
+	}
+}
+
+func replayingCassette(t *testing.T) bool {
+	mode, _ := testAccCassetteMode(t)
+	return mode == CassetteReplay
+}
+
+func testAccCassettePath(t *testing.T) string {
+	return filepath.Join(CassetteDirectory, t.Name()+".yaml")
+}
+
+func cassetteExists(t *testing.T) bool {
+	_, err := os.Stat(testAccCassettePath(t))
+	return err == nil
+}
+
+// Routes the provider's requests for the rest of the test through the test's cassette, if cassettes
+// are enabled
+func useCassette(t *testing.T) {
+	mode, strict := testAccCassetteMode(t)
+	if mode == 0 {
+		return
+	}
+
+	path := testAccCassettePath(t)
+	transport, err := NewCassetteTransport(path, mode, strict, newDefaultTransport())
+	if err != nil {
+		t.Fatalf("%v; record it with %s=record", err, CassetteModeEnvVar)
+	}
+
+	if mode == CassetteReplay {
+		// Credentials are redacted in cassettes, so any will do
+		t.Setenv("QUMULO_HOST", "cassette.invalid")
+		t.Setenv("QUMULO_PORT", "443")
+		t.Setenv("QUMULO_USERNAME", CassetteRedacted)
+		t.Setenv("QUMULO_PASSWORD", CassetteRedacted)
+	}
+
+	testAccCassette = transport
+	t.Cleanup(func() {
+		testAccCassette = nil
+
+		if mode == CassetteRecord && !t.Failed() {
+			if err := transport.Save(); err != nil {
+				t.Errorf("saving cassette: %v", err)
+			}
+		}
+
+		if strict {
+			for _, r := range transport.Unused() {
+				t.Errorf("%s %s was recorded in %s but not replayed", r.Method, r.Uri, path)
+			}
+		}
+	})
+}
+
+func TestCassetteTransport(t *testing.T) {
+	var requests []string
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		w.Header().Set("ETag", `"7"`)
+		if r.URL.Path == AuthEndpoint {
+			w.Write([]byte(`{"bearer_token": "1:secret"}`))
+			return
+		}
+		w.Write([]byte(`{"cluster_name": "qfs"}`))
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "cassette.yaml")
+	host, port, _ := strings.Cut(strings.TrimPrefix(server.URL, "https://"), ":")
+	username, password := "admin", "hunter2"
+	body := ClusterSettingsBody{ClusterName: "qfs"}
+
+	recorder, err := NewCassetteTransport(path, CassetteRecord, false, newDefaultTransport())
+	if err != nil {
+		t.Fatalf("unexpected error creating recorder: %v", err)
+	}
+	c, err := NewClientWithTransport(ctx, &host, &port, &username, &password, recorder)
+	if err != nil {
+		t.Fatalf("unexpected error signing in: %v", err)
+	}
+	if _, err := DoRequest[ClusterSettingsBody, ClusterSettingsBody](ctx, c, PUT, ClusterSettingsEndpoint, &body); err != nil {
+		t.Fatalf("unexpected error recording request: %v", err)
+	}
+	if err := recorder.Save(); err != nil {
+		t.Fatalf("unexpected error saving cassette: %v", err)
+	}
+
+	cassette, _ := os.ReadFile(path)
+	for _, secret := range []string{password, "1:secret", "Bearer"} {
+		if strings.Contains(string(cassette), secret) {
+			t.Errorf("expected %q to be redacted from the cassette:\n%s", secret, cassette)
+		}
+	}
+
+	// Replaying doesn't reach the server, and gives the recorded ETag
+	requests = nil
+	player, err := NewCassetteTransport(path, CassetteReplay, true, nil)
+	if err != nil {
+		t.Fatalf("unexpected error loading cassette: %v", err)
+	}
+	c, err = NewClientWithTransport(ctx, &host, &port, &username, &password, player)
+	if err != nil {
+		t.Fatalf("unexpected error replaying sign in: %v", err)
+	}
+	if _, err := DoRequest[ClusterSettingsBody, ClusterSettingsBody](ctx, c, PUT, ClusterSettingsEndpoint, &body); err != nil {
+		t.Fatalf("unexpected error replaying request: %v", err)
+	}
+	if len(requests) != 0 {
+		t.Errorf("expected no requests to reach the server on replay, got %v", requests)
+	}
+	if c.ETag(ClusterSettingsEndpoint) != `"7"` {
+		t.Errorf("expected the recorded ETag to be replayed, got %q", c.ETag(ClusterSettingsEndpoint))
+	}
+	if unused := player.Unused(); len(unused) != 0 {
+		t.Errorf("expected every interaction to be replayed, got %v unused", unused)
+	}
+
+	// In strict mode, a request with a different body diverges from the recording
+	player, _ = NewCassetteTransport(path, CassetteReplay, true, nil)
+	c.HTTPClient.Transport = player
+	body.ClusterName = "other"
+	if _, err := DoRequest[ClusterSettingsBody, ClusterSettingsBody](ctx, c, PUT, ClusterSettingsEndpoint, &body); err == nil {
+		t.Errorf("expected a diverging request to fail in strict mode")
+	}
+	if unused := player.Unused(); len(unused) != 2 {
+		t.Errorf("expected both interactions to be unused, got %v", unused)
+	}
+
+	player, _ = NewCassetteTransport(path, CassetteReplay, false, nil)
+	c.HTTPClient.Transport = player
+	if _, err := DoRequest[ClusterSettingsBody, ClusterSettingsBody](ctx, c, PUT, ClusterSettingsEndpoint, &body); err != nil {
+		t.Errorf("expected the request to be replayed outside of strict mode, got %v", err)
+	}
+}
+
+func TestRedactCassetteBody(t *testing.T) {
+	body := `{"username": "admin", "password": "hunter2", "users": [{"name": "u", "password": ""}], "limit": 10000000000000000001}`
+	expected := `{"limit":10000000000000000001,"password":"REDACTED","username":"REDACTED","users":[{"name":"u","password":""}]}`
+
+	if got := redactCassetteBody([]byte(body)); got != expected {
+		t.Errorf("expected %s, got %s", expected, got)
+	}
+	if got := redactCassetteBody([]byte("not json")); got != "not json" {
+		t.Errorf("expected a body which isn't JSON to be kept, got %s", got)
+	}
+}
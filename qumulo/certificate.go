@@ -0,0 +1,245 @@
+package qumulo
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const MinimumRsaKeyBits = 2048
+const MinimumEcdsaKeyBits = 256
+const DefaultExpiryWarningDays = 30
+
+// Parses a PEM bundle into its certificates, leaf first
+func parseCertificateChain(pemData string) ([]*x509.Certificate, error) {
+	var certs []*x509.Certificate
+
+	rest := []byte(pemData)
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse certificate %d in chain: %w", len(certs)+1, err)
+		}
+		certs = append(certs, cert)
+	}
+
+	if len(certs) == 0 {
+		return nil, fmt.Errorf("no PEM encoded certificates found")
+	}
+
+	return certs, nil
+}
+
+// SHA-256 fingerprint of the DER encoded certificate, formatted the same way as openssl x509 -fingerprint
+func certificateFingerprint(cert *x509.Certificate) string {
+	sum := sha256.Sum256(cert.Raw)
+
+	octets := make([]string, len(sum))
+	for i, b := range sum {
+		octets[i] = fmt.Sprintf("%02X", b)
+	}
+
+	return strings.Join(octets, ":")
+}
+
+func certificateSubjectAlternativeNames(cert *x509.Certificate) []string {
+	var sans []string
+
+	sans = append(sans, cert.DNSNames...)
+	for _, ip := range cert.IPAddresses {
+		sans = append(sans, ip.String())
+	}
+	sans = append(sans, cert.EmailAddresses...)
+	for _, uri := range cert.URIs {
+		sans = append(sans, uri.String())
+	}
+
+	return sans
+}
+
+// Only the hash of the private key is kept in state
+func hashPrivateKey(v interface{}) string {
+	privateKey, ok := v.(string)
+	if !ok || privateKey == "" {
+		return ""
+	}
+
+	sum := sha256.Sum256([]byte(strings.TrimSpace(privateKey)))
+	return hex.EncodeToString(sum[:])
+}
+
+// Two PEM bundles are equivalent when their leaf certificates have the same fingerprint, regardless of
+// whitespace or whether the intermediates were included
+func suppressEquivalentCertificate(k, old, new string, d *schema.ResourceData) bool {
+	oldCerts, err := parseCertificateChain(old)
+	if err != nil {
+		return false
+	}
+	newCerts, err := parseCertificateChain(new)
+	if err != nil {
+		return false
+	}
+
+	return certificateFingerprint(oldCerts[0]) == certificateFingerprint(newCerts[0])
+}
+
+func flattenCertificateChain(certs []*x509.Certificate) []interface{} {
+	var tfList []interface{}
+
+	for _, cert := range certs {
+		tfMap := map[string]interface{}{}
+
+		tfMap["subject"] = cert.Subject.String()
+		tfMap["issuer"] = cert.Issuer.String()
+		tfMap["not_after"] = cert.NotAfter.UTC().Format(time.RFC3339)
+		tfMap["fingerprint"] = certificateFingerprint(cert)
+
+		tfList = append(tfList, tfMap)
+	}
+	return tfList
+}
+
+func expiryWarningDaysSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:             schema.TypeInt,
+		Optional:         true,
+		Default:          DefaultExpiryWarningDays,
+		ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(0)),
+	}
+}
+
+func validateCertificateValidity(certs []*x509.Certificate, now time.Time) error {
+	for i, cert := range certs {
+		if now.Before(cert.NotBefore) {
+			return fmt.Errorf("certificate %d (%s) is not valid until %s", i+1, cert.Subject, cert.NotBefore.UTC().Format(time.RFC3339))
+		}
+		if now.After(cert.NotAfter) {
+			return fmt.Errorf("certificate %d (%s) expired on %s", i+1, cert.Subject, cert.NotAfter.UTC().Format(time.RFC3339))
+		}
+	}
+
+	return nil
+}
+
+// Checks that every certificate is currently valid and that each one is signed by the next, i.e. the
+// chain is ordered leaf first
+func validateCertificateChain(certs []*x509.Certificate, now time.Time) error {
+	if err := validateCertificateValidity(certs, now); err != nil {
+		return err
+	}
+
+	for i := 0; i+1 < len(certs); i++ {
+		if err := certs[i].CheckSignatureFrom(certs[i+1]); err != nil {
+			return fmt.Errorf("certificate %d in chain (%s) is not signed by certificate %d (%s); the chain must be ordered leaf first: %w",
+				i+1, certs[i].Subject, i+2, certs[i+1].Subject, err)
+		}
+	}
+
+	return nil
+}
+
+func parsePrivateKey(pemData string) (crypto.Signer, error) {
+	block, _ := pem.Decode([]byte(pemData))
+	if block == nil {
+		return nil, fmt.Errorf("no PEM encoded private key found")
+	}
+
+	if key, err := x509.ParsePKCS8PrivateKey(block.Bytes); err == nil {
+		signer, ok := key.(crypto.Signer)
+		if !ok {
+			return nil, fmt.Errorf("unsupported private key type %T", key)
+		}
+		return signer, nil
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	if key, err := x509.ParseECPrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	return nil, fmt.Errorf("unable to parse private key of PEM type %q", block.Type)
+}
+
+// Checks the key type and size, and that the key belongs to the given certificate
+func validatePrivateKey(pemData string, cert *x509.Certificate) error {
+	key, err := parsePrivateKey(pemData)
+	if err != nil {
+		return err
+	}
+
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		if bits := k.N.BitLen(); bits < MinimumRsaKeyBits {
+			return fmt.Errorf("RSA private key is %d bits, at least %d are required", bits, MinimumRsaKeyBits)
+		}
+	case *ecdsa.PrivateKey:
+		if bits := k.Curve.Params().BitSize; bits < MinimumEcdsaKeyBits {
+			return fmt.Errorf("ECDSA private key is %d bits, at least %d are required", bits, MinimumEcdsaKeyBits)
+		}
+	case ed25519.PrivateKey:
+	default:
+		return fmt.Errorf("unsupported private key type %T", key)
+	}
+
+	publicKey, ok := key.Public().(interface{ Equal(crypto.PublicKey) bool })
+	if !ok || !publicKey.Equal(cert.PublicKey) {
+		return fmt.Errorf("private key does not match the certificate (%s)", cert.Subject)
+	}
+
+	return nil
+}
+
+func certificateExpiryWarning(cert *x509.Certificate, warningDays int, now time.Time) diag.Diagnostics {
+	remaining := cert.NotAfter.Sub(now)
+	if remaining > time.Duration(warningDays)*24*time.Hour {
+		return nil
+	}
+
+	summary := fmt.Sprintf("Certificate expires in %d days", int(remaining.Hours()/24))
+	if remaining <= 0 {
+		summary = "Certificate has expired"
+	}
+
+	return diag.Diagnostics{
+		diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  summary,
+			Detail: fmt.Sprintf("The certificate installed on the cluster (%s, fingerprint %s) is valid until %s",
+				cert.Subject, certificateFingerprint(cert), cert.NotAfter.UTC().Format(time.RFC3339)),
+		},
+	}
+}
+
+// Reported by Read when the cluster has no certificate installed, or one which can't be parsed, in which
+// case the details derived from it are left empty rather than failing the refresh
+func unparseableCertificateWarning(description string, err error) diag.Diagnostics {
+	return diag.Diagnostics{
+		diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("Unable to parse the %s installed on the cluster", description),
+			Detail:   err.Error(),
+		},
+	}
+}
@@ -0,0 +1,194 @@
+package qumulo
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+func TestParseCertificateChain(t *testing.T) {
+	leafPem, _ := testGenerateCertificate(t, "leaf.qumulo.test")
+	caPem, _ := testGenerateCertificate(t, "ca.qumulo.test")
+
+	certs, err := parseCertificateChain(leafPem + caPem)
+	if err != nil {
+		t.Fatalf("unexpected error parsing chain: %v", err)
+	}
+	if len(certs) != 2 {
+		t.Fatalf("expected 2 certificates, got %d", len(certs))
+	}
+	if certs[0].Subject.CommonName != "leaf.qumulo.test" {
+		t.Errorf("expected leaf certificate first, got %q", certs[0].Subject.CommonName)
+	}
+
+	if _, err := parseCertificateChain("Not a valid certificate"); err == nil {
+		t.Errorf("expected an error parsing an invalid certificate")
+	}
+}
+
+func TestCertificateFingerprint(t *testing.T) {
+	certPem, _ := testGenerateCertificate(t, "leaf.qumulo.test")
+
+	certs, err := parseCertificateChain(certPem)
+	if err != nil {
+		t.Fatalf("unexpected error parsing certificate: %v", err)
+	}
+
+	fingerprint := certificateFingerprint(certs[0])
+	if len(strings.Split(fingerprint, ":")) != 32 {
+		t.Errorf("expected 32 colon separated octets, got %q", fingerprint)
+	}
+
+	sans := certificateSubjectAlternativeNames(certs[0])
+	if len(sans) != 2 || sans[0] != "leaf.qumulo.test" || sans[1] != "10.0.0.1" {
+		t.Errorf("unexpected subject alternative names %q", sans)
+	}
+}
+
+func TestSuppressEquivalentCertificate(t *testing.T) {
+	certPem, _ := testGenerateCertificate(t, "leaf.qumulo.test")
+	otherPem, _ := testGenerateCertificate(t, "leaf.qumulo.test")
+
+	if !suppressEquivalentCertificate("certificate", certPem, "\n"+certPem+"\n", nil) {
+		t.Errorf("expected whitespace differences to be suppressed")
+	}
+	if suppressEquivalentCertificate("certificate", certPem, otherPem, nil) {
+		t.Errorf("expected a different certificate to produce a diff")
+	}
+}
+
+func TestHashPrivateKey(t *testing.T) {
+	_, keyPem := testGenerateCertificate(t, "leaf.qumulo.test")
+
+	hash := hashPrivateKey(keyPem)
+	if hash == "" || strings.Contains(hash, "PRIVATE KEY") {
+		t.Errorf("expected a hash of the private key, got %q", hash)
+	}
+	if hashPrivateKey(keyPem+"\n") != hash {
+		t.Errorf("expected trailing whitespace to be ignored")
+	}
+	if hashPrivateKey("") != "" {
+		t.Errorf("expected an empty private key to hash to an empty string")
+	}
+}
+
+func TestValidateCertificateChain(t *testing.T) {
+	now := time.Now()
+	ca, caKey, caPem, _ := testIssueCertificate(t, "ca.qumulo.test", now.Add(24*time.Hour), nil, nil)
+	_, _, leafPem, _ := testIssueCertificate(t, "leaf.qumulo.test", now.Add(24*time.Hour), ca, caKey)
+
+	certs, _ := parseCertificateChain(leafPem + caPem)
+	if err := validateCertificateChain(certs, now); err != nil {
+		t.Errorf("unexpected error validating chain: %v", err)
+	}
+
+	certs, _ = parseCertificateChain(caPem + leafPem)
+	if err := validateCertificateChain(certs, now); err == nil {
+		t.Errorf("expected an error validating a chain in the wrong order")
+	}
+
+	certs, _ = parseCertificateChain(leafPem)
+	if err := validateCertificateChain(certs, now.Add(48*time.Hour)); err == nil {
+		t.Errorf("expected an error validating an expired certificate")
+	}
+}
+
+func TestValidatePrivateKey(t *testing.T) {
+	certPem, keyPem := testGenerateCertificate(t, "leaf.qumulo.test")
+	_, otherKeyPem := testGenerateCertificate(t, "leaf.qumulo.test")
+
+	certs, _ := parseCertificateChain(certPem)
+	if err := validatePrivateKey(keyPem, certs[0]); err != nil {
+		t.Errorf("unexpected error validating matching key: %v", err)
+	}
+	if err := validatePrivateKey(otherKeyPem, certs[0]); err == nil {
+		t.Errorf("expected an error validating a key which doesn't match the certificate")
+	}
+
+	weakKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatalf("unable to generate key: %v", err)
+	}
+	weakKeyPem := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(weakKey)})
+	if err := validatePrivateKey(string(weakKeyPem), certs[0]); err == nil || !strings.Contains(err.Error(), "1024 bits") {
+		t.Errorf("expected an error validating a 1024 bit RSA key, got %v", err)
+	}
+}
+
+func TestCertificateExpiryWarning(t *testing.T) {
+	now := time.Now()
+	cert, _, _, _ := testIssueCertificate(t, "leaf.qumulo.test", now.Add(10*24*time.Hour), nil, nil)
+
+	if diags := certificateExpiryWarning(cert, 30, now); len(diags) != 1 || diags[0].Severity != diag.Warning {
+		t.Errorf("expected a warning for a certificate expiring within 30 days, got %v", diags)
+	}
+	if diags := certificateExpiryWarning(cert, 7, now); len(diags) != 0 {
+		t.Errorf("expected no warning for a certificate expiring after 7 days, got %v", diags)
+	}
+}
+
+func testGenerateCertificate(t *testing.T, commonName string) (string, string) {
+	_, _, certPem, keyPem := testIssueCertificate(t, commonName, time.Now().Add(24*time.Hour), nil, nil)
+	return certPem, keyPem
+}
+
+// Issues a certificate signed by issuer, or a self-signed one when issuer is nil
+func testIssueCertificate(t *testing.T, commonName string, notAfter time.Time, issuer *x509.Certificate, issuerKey crypto.Signer) (*x509.Certificate, crypto.Signer, string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("unable to generate key: %v", err)
+	}
+
+	cert, certPem, keyPem := testIssueCertificateForKey(t, commonName, notAfter, key, issuer, issuerKey)
+	return cert, key, certPem, keyPem
+}
+
+// Issues a certificate for an existing key, such as when a certificate is renewed without a new key
+func testIssueCertificateForKey(t *testing.T, commonName string, notAfter time.Time, key *ecdsa.PrivateKey, issuer *x509.Certificate, issuerKey crypto.Signer) (*x509.Certificate, string, string) {
+	template := x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: commonName, Organization: []string{"Qumulo"}},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              notAfter,
+		DNSNames:              []string{commonName},
+		IPAddresses:           []net.IP{net.ParseIP("10.0.0.1")},
+		BasicConstraintsValid: true,
+		IsCA:                  issuer == nil,
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+	}
+	if issuer == nil {
+		issuer = &template
+		issuerKey = key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, &template, issuer, &key.PublicKey, issuerKey)
+	if err != nil {
+		t.Fatalf("unable to create certificate: %v", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("unable to parse certificate: %v", err)
+	}
+
+	keyDer, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatalf("unable to marshal key: %v", err)
+	}
+
+	certPem := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPem := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDer})
+
+	return cert, string(certPem), string(keyPem)
+}
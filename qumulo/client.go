@@ -9,6 +9,7 @@ import (
 	"io/ioutil"
 	"net/http"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
@@ -33,6 +34,41 @@ type Client struct {
 	HTTPClient  *http.Client
 	BearerToken string
 	Auth        AuthStruct
+	// Refuses every request but GET and signing in, so that nothing on the cluster is modified
+	ReadOnly bool
+
+	clusterUuid      string
+	clusterUuidMutex sync.Mutex
+
+	// Singleton resource types planned so far on each cluster, and the plan which claimed each
+	singletons      map[string]*singletonClaim
+	singletonsMutex sync.Mutex
+
+	// Last ETag seen for each endpoint, sent back as If-Match when the endpoint is modified
+	etags      map[string]string
+	etagsMutex sync.Mutex
+}
+
+// Returned when a PUT or PATCH is rejected because the settings changed since they were last read
+type ConflictError struct {
+	Method      Method
+	EndpointUri string
+}
+
+func (e ConflictError) Error() string {
+	return fmt.Sprintf("%s %s was rejected because the settings were modified outside of this Terraform run since they were "+
+		"last read; run `terraform apply -refresh-only` to review the changes, then plan and apply again", e.Method, e.EndpointUri)
+}
+
+// Returned instead of sending a request which could modify the cluster when the client is read only
+type ReadOnlyError struct {
+	Method      Method
+	EndpointUri string
+}
+
+func (e ReadOnlyError) Error() string {
+	return fmt.Sprintf("%s %s was refused because the provider is read only, which only allows GET requests; "+
+		"unset read_only and QUMULO_READ_ONLY to modify the cluster", e.Method, e.EndpointUri)
 }
 
 type AuthStruct struct {
@@ -47,14 +83,22 @@ type AuthResponse struct {
 }
 
 func NewClient(ctx context.Context, host, port, username, password *string) (*Client, error) {
-	HostURL := fmt.Sprintf("https://%s:%s", *host, *port)
+	return NewClientWithTransport(ctx, host, port, username, password, newDefaultTransport())
+}
 
-	transCfg := &http.Transport{
+func newDefaultTransport() http.RoundTripper {
+	return &http.Transport{
 		TLSClientConfig: &tls.Config{InsecureSkipVerify: true}, // ignore expired SSL certificates
 	}
+}
+
+// Creates a client which sends its requests through the given transport, e.g. a CassetteTransport
+// which records or replays the interactions with a cluster
+func NewClientWithTransport(ctx context.Context, host, port, username, password *string, transport http.RoundTripper) (*Client, error) {
+	HostURL := fmt.Sprintf("https://%s:%s", *host, *port)
 
 	c := Client{
-		HTTPClient: &http.Client{Timeout: 10 * time.Second, Transport: transCfg},
+		HTTPClient: &http.Client{Timeout: 10 * time.Second, Transport: transport},
 		HostURL:    HostURL,
 		Auth: AuthStruct{
 			Username: *username,
@@ -79,6 +123,10 @@ func NewClient(ctx context.Context, host, port, username, password *string) (*Cl
 }
 
 func DoRequest[RQ interface{}, R interface{}](ctx context.Context, client *Client, method Method, endpointUri string, reqBody *RQ) (*R, error) {
+	if client.ReadOnly && method != GET && endpointUri != AuthEndpoint {
+		return nil, ReadOnlyError{Method: method, EndpointUri: endpointUri}
+	}
+
 	bearerToken := "Bearer " + client.BearerToken
 	HostURL := client.HostURL
 
@@ -101,16 +149,32 @@ func DoRequest[RQ interface{}, R interface{}](ctx context.Context, client *Clien
 	req.Header.Set("Authorization", bearerToken)
 	req.Header.Add("Content-Type", "application/json")
 
+	if method == PUT || method == PATCH {
+		if etag := client.ETag(endpointUri); etag != "" {
+			req.Header.Set("If-Match", etag)
+		}
+	}
+
 	tflog.Trace(ctx, "Executing API request", map[string]interface{}{
 		"url":    url,
 		"method": method.String(),
 	})
 
-	body, err := client.makeHTTPRequest(req)
+	body, header, err := client.makeHTTPRequest(req)
 	if err != nil {
+		if _, ok := err.(preconditionFailedError); ok {
+			return nil, ConflictError{Method: method, EndpointUri: endpointUri}
+		}
 		return nil, err
 	}
 
+	switch method {
+	case GET, PUT, PATCH:
+		client.SetETag(endpointUri, header.Get("ETag"))
+	case DELETE:
+		client.SetETag(endpointUri, "")
+	}
+
 	var cr R
 	if len(body) == 0 {
 		return nil, nil
@@ -123,22 +187,57 @@ func DoRequest[RQ interface{}, R interface{}](ctx context.Context, client *Clien
 	return &cr, nil
 }
 
-func (c *Client) makeHTTPRequest(req *http.Request) ([]byte, error) {
+type preconditionFailedError struct {
+	body []byte
+}
+
+func (e preconditionFailedError) Error() string {
+	return fmt.Sprintf("status: %d, body: %s", http.StatusPreconditionFailed, e.body)
+}
+
+func (c *Client) makeHTTPRequest(req *http.Request) ([]byte, http.Header, error) {
 
 	res, err := c.HTTPClient.Do(req)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	defer res.Body.Close()
 
 	body, err := ioutil.ReadAll(res.Body)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+
+	if res.StatusCode == http.StatusPreconditionFailed {
+		return nil, nil, preconditionFailedError{body: body}
 	}
 
 	if res.StatusCode != http.StatusOK && res.StatusCode != http.StatusAccepted {
-		return nil, fmt.Errorf("status: %d, body: %s", res.StatusCode, body)
+		return nil, nil, fmt.Errorf("status: %d, body: %s", res.StatusCode, body)
+	}
+
+	return body, res.Header, err
+}
+
+func (c *Client) ETag(endpointUri string) string {
+	c.etagsMutex.Lock()
+	defer c.etagsMutex.Unlock()
+
+	return c.etags[endpointUri]
+}
+
+// Records the ETag of an endpoint, or forgets it when the endpoint didn't return one
+func (c *Client) SetETag(endpointUri, etag string) {
+	c.etagsMutex.Lock()
+	defer c.etagsMutex.Unlock()
+
+	if etag == "" {
+		delete(c.etags, endpointUri)
+		return
 	}
 
-	return body, err
+	if c.etags == nil {
+		c.etags = map[string]string{}
+	}
+	c.etags[endpointUri] = etag
 }
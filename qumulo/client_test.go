@@ -0,0 +1,81 @@
+package qumulo
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestDoRequestETag(t *testing.T) {
+	etag := `"1"`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPut {
+			if r.Header.Get("If-Match") != etag {
+				w.WriteHeader(http.StatusPreconditionFailed)
+				return
+			}
+			etag = `"2"`
+		}
+		w.Header().Set("ETag", etag)
+		w.Write([]byte(`{"cluster_name": "qfs"}`))
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	c := &Client{HostURL: server.URL, HTTPClient: server.Client()}
+	body := ClusterSettingsBody{ClusterName: "qfs"}
+
+	if _, err := DoRequest[ClusterSettingsBody, ClusterSettingsBody](ctx, c, GET, ClusterSettingsEndpoint, nil); err != nil {
+		t.Fatalf("unexpected error reading settings: %v", err)
+	}
+	if c.ETag(ClusterSettingsEndpoint) != `"1"` {
+		t.Fatalf("expected the ETag to be captured on GET, got %q", c.ETag(ClusterSettingsEndpoint))
+	}
+
+	if _, err := DoRequest[ClusterSettingsBody, ClusterSettingsBody](ctx, c, PUT, ClusterSettingsEndpoint, &body); err != nil {
+		t.Fatalf("unexpected error updating settings with a current ETag: %v", err)
+	}
+	if c.ETag(ClusterSettingsEndpoint) != `"2"` {
+		t.Fatalf("expected the ETag to be updated after PUT, got %q", c.ETag(ClusterSettingsEndpoint))
+	}
+
+	// Someone else modified the settings since they were last read
+	c.SetETag(ClusterSettingsEndpoint, `"1"`)
+	_, err := DoRequest[ClusterSettingsBody, ClusterSettingsBody](ctx, c, PUT, ClusterSettingsEndpoint, &body)
+	if !errors.As(err, &ConflictError{}) {
+		t.Errorf("expected a conflict error updating with a stale ETag, got %v", err)
+	}
+}
+
+func TestDoRequestReadOnly(t *testing.T) {
+	var methods []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		methods = append(methods, r.Method)
+		w.Write([]byte(`{"cluster_name": "qfs", "bearer_token": "token"}`))
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	c := &Client{HostURL: server.URL, HTTPClient: server.Client(), ReadOnly: true}
+	body := ClusterSettingsBody{ClusterName: "qfs"}
+
+	if _, err := DoRequest[ClusterSettingsBody, ClusterSettingsBody](ctx, c, GET, ClusterSettingsEndpoint, nil); err != nil {
+		t.Errorf("unexpected error reading settings: %v", err)
+	}
+	// Signing in doesn't modify the cluster
+	if _, err := DoRequest[AuthStruct, AuthResponse](ctx, c, POST, AuthEndpoint, &AuthStruct{}); err != nil {
+		t.Errorf("unexpected error signing in: %v", err)
+	}
+
+	for _, method := range []Method{PUT, POST, PATCH, DELETE} {
+		_, err := DoRequest[ClusterSettingsBody, ClusterSettingsBody](ctx, c, method, ClusterSettingsEndpoint, &body)
+		if !errors.As(err, &ReadOnlyError{}) {
+			t.Errorf("expected %s to be refused, got %v", method, err)
+		}
+	}
+	if len(methods) != 2 {
+		t.Errorf("expected only the GET and sign in requests to be sent, got %v", methods)
+	}
+}
@@ -0,0 +1,48 @@
+// Code generated by tools/resourcegen from qumulo_api.json. DO NOT EDIT.
+
+package qumulo
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// The configuration of audit logging to Amazon CloudWatch
+type CloudWatchConfigBody struct {
+	Enabled      bool   `json:"enabled"`
+	LogGroupName string `json:"log_group_name"`
+	Region       string `json:"region"`
+}
+
+func cloudWatchConfigBodySchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"enabled": {
+			Type:     schema.TypeBool,
+			Required: true,
+		},
+		"log_group_name": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		"region": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+	}
+}
+
+func expandCloudWatchConfigBody(d *schema.ResourceData) CloudWatchConfigBody {
+	cloudWatchConfigBody := CloudWatchConfigBody{}
+	cloudWatchConfigBody.Enabled = d.Get("enabled").(bool)
+	cloudWatchConfigBody.LogGroupName = d.Get("log_group_name").(string)
+	cloudWatchConfigBody.Region = d.Get("region").(string)
+	return cloudWatchConfigBody
+}
+
+func flattenCloudWatchConfigBody(d *schema.ResourceData, cloudWatchConfigBody *CloudWatchConfigBody) diag.Diagnostics {
+	var errs ErrorCollection
+	errs.addMaybeError(d.Set("enabled", cloudWatchConfigBody.Enabled))
+	errs.addMaybeError(d.Set("log_group_name", cloudWatchConfigBody.LogGroupName))
+	errs.addMaybeError(d.Set("region", cloudWatchConfigBody.Region))
+	return errs.diags
+}
@@ -0,0 +1,34 @@
+// Code generated by tools/resourcegen from qumulo_api.json. DO NOT EDIT.
+
+package qumulo
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// The settings of the cluster as a whole
+type ClusterSettingsBody struct {
+	ClusterName string `json:"cluster_name"`
+}
+
+func clusterSettingsBodySchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"cluster_name": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+	}
+}
+
+func expandClusterSettingsBody(d *schema.ResourceData) ClusterSettingsBody {
+	clusterSettingsBody := ClusterSettingsBody{}
+	clusterSettingsBody.ClusterName = d.Get("cluster_name").(string)
+	return clusterSettingsBody
+}
+
+func flattenClusterSettingsBody(d *schema.ResourceData, clusterSettingsBody *ClusterSettingsBody) diag.Diagnostics {
+	var errs ErrorCollection
+	errs.addMaybeError(d.Set("cluster_name", clusterSettingsBody.ClusterName))
+	return errs.diags
+}
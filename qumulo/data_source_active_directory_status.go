@@ -0,0 +1,241 @@
+package qumulo
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceActiveDirectoryStatus() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceActiveDirectoryStatusRead,
+
+		Timeouts: &schema.ResourceTimeout{
+			Read: schema.DefaultTimeout(1 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"status": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"domain": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"domain_netbios": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"ou": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"use_ad_posix_attributes": &schema.Schema{
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"base_dn": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"dcs":                    activeDirectoryDcsSchema(),
+			"ldap_connection_states": ldapConnectionStatesSchema(),
+			"last_action_time": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"last_error": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"module": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"error_class": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"description": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"user_visible": &schema.Schema{
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceActiveDirectoryStatusRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+
+	var errs ErrorCollection
+
+	adStatus, err := DoRequest[ActiveDirectoryStatusBody, ActiveDirectoryStatusBody](ctx, c, GET, AdStatusEndpoint, nil)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	tflog.Debug(ctx, "Active Directory status:", map[string]interface{}{
+		"adStatus": adStatus.Status,
+	})
+
+	errs.addMaybeError(d.Set("status", adStatus.Status))
+	errs.addMaybeError(d.Set("domain", adStatus.Domain))
+	errs.addMaybeError(d.Set("domain_netbios", adStatus.DomainNetBios))
+	errs.addMaybeError(d.Set("ou", adStatus.Ou))
+	errs.addMaybeError(d.Set("use_ad_posix_attributes", adStatus.UseAdPosixAttributes))
+	errs.addMaybeError(d.Set("base_dn", adStatus.BaseDn))
+	errs.addMaybeError(d.Set("dcs", flattenActiveDirectoryDcs(adStatus.Dcs)))
+	errs.addMaybeError(d.Set("ldap_connection_states", flattenLdapConnectionStates(adStatus.LdapConnectionStates)))
+
+	// The monitor endpoint reports the outcome of the most recent join, leave or reconfigure operation
+	adMonitor, err := DoRequest[ActiveDirectoryMonitorResponse, ActiveDirectoryMonitorResponse](ctx, c, GET, AdMonitorEndpoint, nil)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	errs.addMaybeError(d.Set("last_action_time", adMonitor.LastActionTime))
+	errs.addMaybeError(d.Set("last_error", flattenActiveDirectoryMonitorLastError(adMonitor.LastError)))
+
+	d.SetId(strconv.FormatInt(time.Now().Unix(), 10))
+
+	return errs.diags
+}
+
+func ldapConnectionStatesSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Computed: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"node_id": &schema.Schema{
+					Type:     schema.TypeInt,
+					Computed: true,
+				},
+				"servers": &schema.Schema{
+					Type:     schema.TypeList,
+					Computed: true,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"bind_uri": &schema.Schema{
+								Type:     schema.TypeString,
+								Computed: true,
+							},
+							"kdc_address": &schema.Schema{
+								Type:     schema.TypeString,
+								Computed: true,
+							},
+						},
+					},
+				},
+				"bind_domain": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"bind_account": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"base_dn_vec": &schema.Schema{
+					Type:     schema.TypeList,
+					Computed: true,
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
+					},
+				},
+				"health": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+			},
+		},
+	}
+}
+
+func activeDirectoryDcsSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Computed: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"name": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"address": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+			},
+		},
+	}
+}
+
+func flattenActiveDirectoryDcs(dcs []ActiveDirectoryDcs) []interface{} {
+	var tfList []interface{}
+
+	for _, dc := range dcs {
+		tfMap := map[string]interface{}{}
+
+		tfMap["name"] = dc.Name
+		tfMap["address"] = dc.Address
+
+		tfList = append(tfList, tfMap)
+	}
+	return tfList
+}
+
+func flattenLdapConnectionStates(states []ActiveDirectoryLdapStates) []interface{} {
+	var tfList []interface{}
+
+	for _, state := range states {
+		tfMap := map[string]interface{}{}
+
+		tfMap["node_id"] = state.NodeId
+		tfMap["bind_domain"] = state.BindDomain
+		tfMap["bind_account"] = state.BindAccount
+		tfMap["base_dn_vec"] = state.BaseDnVec
+		tfMap["health"] = state.Health
+
+		var serverList []interface{}
+		for _, server := range state.Servers {
+			serverMap := map[string]interface{}{}
+			serverMap["bind_uri"] = server.BindUri
+			serverMap["kdc_address"] = server.KdcAddress
+			serverList = append(serverList, serverMap)
+		}
+		tfMap["servers"] = serverList
+
+		tfList = append(tfList, tfMap)
+	}
+	return tfList
+}
+
+func flattenActiveDirectoryMonitorLastError(lastError ActiveDirectoryMonitorLastError) []interface{} {
+	// The API returns an empty error object when the last operation succeeded
+	if lastError.ErrorClass == "" && lastError.Description == "" {
+		return nil
+	}
+
+	tfMap := map[string]interface{}{}
+
+	tfMap["module"] = lastError.Module
+	tfMap["error_class"] = lastError.ErrorClass
+	tfMap["description"] = lastError.Description
+	tfMap["user_visible"] = lastError.UserVisible
+
+	return []interface{}{tfMap}
+}
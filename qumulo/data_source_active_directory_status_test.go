@@ -0,0 +1,62 @@
+package qumulo
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccReadActiveDirectoryStatus(t *testing.T) {
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccActiveDirectoryConfigFull(defaultActiveDirectoryConfig) + testAccActiveDirectoryStatusConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.qumulo_ad_status.status", "domain",
+						defaultActiveDirectoryConfig.JoinSettings.Domain),
+					testAccCheckActiveDirectoryStatusDataSource(),
+				),
+			},
+		},
+	})
+}
+
+const testAccActiveDirectoryStatusConfig = `
+data "qumulo_ad_status" "status" {
+	depends_on = [qumulo_ad_settings.ad_settings]
+}
+`
+
+func testAccCheckActiveDirectoryStatusDataSource() resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		status, ok := s.RootModule().Resources["data.qumulo_ad_status.status"]
+		if !ok {
+			//lint:ignore ST1005 proper nouns should be capitalized
+			return fmt.Errorf("Active Directory status data source not found")
+		}
+
+		c := testAccProvider.Meta().(*Client)
+		ctx := context.Background()
+
+		adStatus, err := DoRequest[ActiveDirectoryStatusBody, ActiveDirectoryStatusBody](ctx, c, GET, AdStatusEndpoint, nil)
+		if err != nil {
+			return err
+		}
+
+		if v := status.Primary.Attributes["status"]; v != adStatus.Status {
+			//lint:ignore ST1005 proper nouns should be capitalized
+			return fmt.Errorf("Active Directory status mismatch: Expected %v, got %v", adStatus.Status, v)
+		}
+		if v := status.Primary.Attributes["dcs.#"]; v != strconv.Itoa(len(adStatus.Dcs)) {
+			return fmt.Errorf("domain controller count mismatch: Expected %v, got %v", len(adStatus.Dcs), v)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,132 @@
+package qumulo
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const DirectoryQuotaStatusEndpoint = "/v1/files/quotas/status/"
+
+type DirectoryQuotaStatusResponse struct {
+	Id            string `json:"id"`
+	Path          string `json:"path"`
+	Limit         string `json:"limit"`
+	CapacityUsage string `json:"capacity_usage"`
+}
+
+type DirectoryQuotaStatusListResponse struct {
+	Quotas []DirectoryQuotaStatusResponse `json:"quotas"`
+	Paging Paging                         `json:"paging"`
+}
+
+type Paging struct {
+	Next string `json:"next"`
+}
+
+func dataSourceDirectoryQuotas() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceDirectoryQuotasRead,
+
+		Timeouts: &schema.ResourceTimeout{
+			Read: schema.DefaultTimeout(1 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"quotas": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"directory_id": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"path": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"limit": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"capacity_used": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"percent_used": &schema.Schema{
+							Type:     schema.TypeFloat,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceDirectoryQuotasRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+
+	var quotas []DirectoryQuotaStatusResponse
+
+	// The quota status listing is paginated; follow the next link until it runs out
+	nextUri := DirectoryQuotaStatusEndpoint
+	for nextUri != "" {
+		tflog.Debug(ctx, "Reading directory quota status page", map[string]interface{}{
+			"uri": nextUri,
+		})
+
+		quotaPage, err := DoRequest[DirectoryQuotaEmptyBody, DirectoryQuotaStatusListResponse](ctx, c, GET, nextUri, nil)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		quotas = append(quotas, quotaPage.Quotas...)
+		nextUri = quotaPage.Paging.Next
+	}
+
+	if err := d.Set("quotas", flattenDirectoryQuotaStatuses(quotas)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(strconv.FormatInt(time.Now().Unix(), 10))
+
+	return nil
+}
+
+func flattenDirectoryQuotaStatuses(quotas []DirectoryQuotaStatusResponse) []interface{} {
+	var tfList []interface{}
+
+	for _, quota := range quotas {
+		tfMap := map[string]interface{}{}
+
+		tfMap["directory_id"] = quota.Id
+		tfMap["path"] = quota.Path
+		tfMap["limit"] = quota.Limit
+		tfMap["capacity_used"] = quota.CapacityUsage
+		tfMap["percent_used"] = directoryQuotaPercentUsed(quota.Limit, quota.CapacityUsage)
+
+		tfList = append(tfList, tfMap)
+	}
+	return tfList
+}
+
+func directoryQuotaPercentUsed(limit string, capacityUsage string) float64 {
+	limitBytes, err := strconv.ParseFloat(limit, 64)
+	if err != nil || limitBytes == 0 {
+		return 0
+	}
+
+	usedBytes, err := strconv.ParseFloat(capacityUsage, 64)
+	if err != nil {
+		return 0
+	}
+
+	return usedBytes / limitBytes * 100
+}
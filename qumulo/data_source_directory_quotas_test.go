@@ -0,0 +1,79 @@
+package qumulo
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccReadDirectoryQuotas(t *testing.T) {
+	quota := DirectoryQuotaBody{
+		Id:    testAccDirectory(t, TestNamePrefix+"_quotas"),
+		Limit: defaultDirectoryQuotaLimit,
+	}
+
+	resource.Test(t, resource.TestCase{
+		// The pre-check is done by testAccDirectory
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDirectoryQuotasDataSourceConfig(quota),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("qumulo_directory_quota.test_quota", "capacity_used"),
+					testAccCheckDirectoryQuotasDataSource(quota),
+				),
+			},
+		},
+	})
+}
+
+func testAccDirectoryQuotasDataSourceConfig(req DirectoryQuotaBody) string {
+	return fmt.Sprintf(`
+	resource "qumulo_directory_quota" "test_quota" {
+		directory_id = %v
+		limit = %q
+	}
+
+	data "qumulo_directory_quotas" "all" {
+		depends_on = [qumulo_directory_quota.test_quota]
+	}
+  `, req.Id, req.Limit)
+}
+
+func testAccCheckDirectoryQuotasDataSource(quota DirectoryQuotaBody) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		quotas, ok := s.RootModule().Resources["data.qumulo_directory_quotas.all"]
+		if !ok {
+			return fmt.Errorf("directory quotas data source not found")
+		}
+
+		c := testAccProvider.Meta().(*Client)
+		ctx := context.Background()
+		quotaStatusUrl := DirectoryQuotaStatusEndpoint + quota.Id
+
+		quotaStatus, err := DoRequest[DirectoryQuotaEmptyBody, DirectoryQuotaStatusResponse](ctx, c, GET, quotaStatusUrl, nil)
+		if err != nil {
+			return err
+		}
+
+		for key, value := range quotas.Primary.Attributes {
+			if value != quota.Id || !strings.HasSuffix(key, ".directory_id") {
+				continue
+			}
+			prefix := strings.TrimSuffix(key, "directory_id")
+			if v := quotas.Primary.Attributes[prefix+"limit"]; v != quota.Limit {
+				return fmt.Errorf("directory quota limit mismatch: Expected %v, got %v", quota.Limit, v)
+			}
+			if v := quotas.Primary.Attributes[prefix+"path"]; v != quotaStatus.Path {
+				return fmt.Errorf("directory quota path mismatch: Expected %v, got %v", quotaStatus.Path, v)
+			}
+			return nil
+		}
+
+		return fmt.Errorf("directory quota with id %q not found in data source", quota.Id)
+	}
+}
@@ -0,0 +1,68 @@
+package qumulo
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const LdapStatusEndpoint = "/v1/ldap/status"
+
+type LdapStatusResponse struct {
+	LdapConnectionStates []ActiveDirectoryLdapStates `json:"ldap_connection_states"`
+}
+
+func dataSourceLdapStatus() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceLdapStatusRead,
+
+		Timeouts: &schema.ResourceTimeout{
+			Read: schema.DefaultTimeout(1 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"use_ldap": &schema.Schema{
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"bind_uri": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"base_distinguished_names": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"ldap_connection_states": ldapConnectionStatesSchema(),
+		},
+	}
+}
+
+func dataSourceLdapStatusRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+
+	var errs ErrorCollection
+
+	ls, err := DoRequest[LdapServerSettingsBody, LdapServerSettingsBody](ctx, c, GET, LdapServerEndpoint, nil)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	errs.addMaybeError(d.Set("use_ldap", ls.UseLdap))
+	errs.addMaybeError(d.Set("bind_uri", ls.BindUri))
+	errs.addMaybeError(d.Set("base_distinguished_names", ls.BaseDistinguishedNames))
+
+	ldapStatus, err := DoRequest[LdapStatusResponse, LdapStatusResponse](ctx, c, GET, LdapStatusEndpoint, nil)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	errs.addMaybeError(d.Set("ldap_connection_states", flattenLdapConnectionStates(ldapStatus.LdapConnectionStates)))
+
+	d.SetId(strconv.FormatInt(time.Now().Unix(), 10))
+
+	return errs.diags
+}
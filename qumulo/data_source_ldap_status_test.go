@@ -0,0 +1,59 @@
+package qumulo
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccReadLdapStatus(t *testing.T) {
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccLdapServerConfig(testingLdapServerConfig) + testAccLdapStatusConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.qumulo_ldap_status.status", "bind_uri",
+						testingLdapServerConfig.BindUri),
+					testAccCheckLdapStatusDataSource(),
+				),
+			},
+		},
+	})
+}
+
+const testAccLdapStatusConfig = `
+data "qumulo_ldap_status" "status" {
+	depends_on = [qumulo_ldap_server.some_ldap_server]
+}
+`
+
+func testAccCheckLdapStatusDataSource() resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		status, ok := s.RootModule().Resources["data.qumulo_ldap_status.status"]
+		if !ok {
+			//lint:ignore ST1005 proper nouns should be capitalized
+			return fmt.Errorf("LDAP status data source not found")
+		}
+
+		c := testAccProvider.Meta().(*Client)
+		ctx := context.Background()
+
+		ldapStatus, err := DoRequest[LdapStatusResponse, LdapStatusResponse](ctx, c, GET, LdapStatusEndpoint, nil)
+		if err != nil {
+			return err
+		}
+
+		if v := status.Primary.Attributes["ldap_connection_states.#"]; v != strconv.Itoa(len(ldapStatus.LdapConnectionStates)) {
+			//lint:ignore ST1005 proper nouns should be capitalized
+			return fmt.Errorf("LDAP connection state count mismatch: Expected %v, got %v", len(ldapStatus.LdapConnectionStates), v)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,323 @@
+package qumulo
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const ClusterNodesEndpoint = "/v1/cluster/nodes/"
+const ClusterNodesUptimeEndpoint = "/v1/cluster/nodes/uptime"
+const ClusterSlotsEndpoint = "/v1/cluster/slots/"
+const InterfaceStatusSuffix = "/status/"
+
+// Node statuses which mean the node is healthy. A node with any other status makes the cluster degraded.
+var ClusterNodeHealthyStatuses = []string{"online"}
+
+// Slot states which mean the cluster is running with reduced protection
+var ClusterSlotUnhealthyStates = []string{"missing", "dead"}
+
+type ClusterNode struct {
+	Id           int    `json:"id"`
+	NodeStatus   string `json:"node_status"`
+	NodeName     string `json:"node_name"`
+	Uuid         string `json:"uuid"`
+	Label        string `json:"label"`
+	ModelNumber  string `json:"model_number"`
+	SerialNumber string `json:"serial_number"`
+	MacAddress   string `json:"mac_address"`
+}
+
+type ClusterNodeUptime struct {
+	Id     int    `json:"id"`
+	Uptime string `json:"uptime"`
+}
+
+type ClusterSlot struct {
+	Id               string `json:"id"`
+	NodeId           int    `json:"node_id"`
+	Slot             int    `json:"slot"`
+	State            string `json:"state"`
+	SlotType         string `json:"slot_type"`
+	DiskType         string `json:"disk_type"`
+	DiskModel        string `json:"disk_model"`
+	DiskSerialNumber string `json:"disk_serial_number"`
+	Capacity         string `json:"capacity"`
+	HighEndurance    bool   `json:"high_endurance"`
+	LedPattern       string `json:"led_pattern"`
+}
+
+type InterfaceNodeStatus struct {
+	NodeId          int                      `json:"node_id"`
+	NetworkStatuses []InterfaceNetworkStatus `json:"network_statuses"`
+}
+
+type InterfaceNetworkStatus struct {
+	Address           string   `json:"address"`
+	FloatingAddresses []string `json:"floating_addresses"`
+}
+
+type ClusterNodesEmptyBody struct{}
+
+func dataSourceNodes() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceNodesRead,
+
+		Timeouts: &schema.ResourceTimeout{
+			Read: schema.DefaultTimeout(1 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"degraded": &schema.Schema{
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"nodes": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": &schema.Schema{
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"uuid": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"node_name": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"node_status": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"label": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"model_number": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"serial_number": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"mac_address": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"uptime": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"ip_addresses": &schema.Schema{
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"floating_ip_addresses": &schema.Schema{
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"slots": &schema.Schema{
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"id": &schema.Schema{
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"slot": &schema.Schema{
+										Type:     schema.TypeInt,
+										Computed: true,
+									},
+									"state": &schema.Schema{
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"slot_type": &schema.Schema{
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"disk_type": &schema.Schema{
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"disk_model": &schema.Schema{
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"disk_serial_number": &schema.Schema{
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"capacity": &schema.Schema{
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"high_endurance": &schema.Schema{
+										Type:     schema.TypeBool,
+										Computed: true,
+									},
+									"led_pattern": &schema.Schema{
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceNodesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+
+	var errs ErrorCollection
+
+	nodes, err := DoRequest[ClusterNodesEmptyBody, []ClusterNode](ctx, c, GET, ClusterNodesEndpoint, nil)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	nodeUptimes, err := DoRequest[ClusterNodesEmptyBody, []ClusterNodeUptime](ctx, c, GET, ClusterNodesUptimeEndpoint, nil)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	slots, err := DoRequest[ClusterNodesEmptyBody, []ClusterSlot](ctx, c, GET, ClusterSlotsEndpoint, nil)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	nodeStatuses, err := readInterfaceNodeStatuses(ctx, c)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	degraded := false
+	for _, node := range *nodes {
+		if !StringSliceContains(ClusterNodeHealthyStatuses, node.NodeStatus) {
+			tflog.Warn(ctx, "Node is not online", map[string]interface{}{
+				"node_id":     node.Id,
+				"node_status": node.NodeStatus,
+			})
+			degraded = true
+		}
+	}
+	for _, slot := range *slots {
+		if StringSliceContains(ClusterSlotUnhealthyStates, slot.State) {
+			tflog.Warn(ctx, "Disk slot is unhealthy", map[string]interface{}{
+				"slot_id": slot.Id,
+				"state":   slot.State,
+			})
+			degraded = true
+		}
+	}
+
+	errs.addMaybeError(d.Set("degraded", degraded))
+	errs.addMaybeError(d.Set("nodes", flattenClusterNodes(*nodes, *nodeUptimes, *slots, nodeStatuses)))
+
+	d.SetId(strconv.FormatInt(time.Now().Unix(), 10))
+
+	return errs.diags
+}
+
+func readInterfaceNodeStatuses(ctx context.Context, c *Client) ([]InterfaceNodeStatus, error) {
+	interfaces, err := DoRequest[ClusterNodesEmptyBody, []InterfaceConfigurationResponse](ctx, c, GET, InterfaceConfigurationEndpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var nodeStatuses []InterfaceNodeStatus
+	for _, networkInterface := range *interfaces {
+		readInterfaceStatusUri := InterfaceConfigurationEndpoint + strconv.Itoa(networkInterface.Id) + InterfaceStatusSuffix
+
+		interfaceStatuses, err := DoRequest[ClusterNodesEmptyBody, []InterfaceNodeStatus](ctx, c, GET, readInterfaceStatusUri, nil)
+		if err != nil {
+			return nil, err
+		}
+		nodeStatuses = append(nodeStatuses, *interfaceStatuses...)
+	}
+
+	return nodeStatuses, nil
+}
+
+func flattenClusterNodes(nodes []ClusterNode, nodeUptimes []ClusterNodeUptime, slots []ClusterSlot, nodeStatuses []InterfaceNodeStatus) []interface{} {
+	var tfList []interface{}
+
+	for _, node := range nodes {
+		tfMap := map[string]interface{}{}
+
+		tfMap["id"] = node.Id
+		tfMap["uuid"] = node.Uuid
+		tfMap["node_name"] = node.NodeName
+		tfMap["node_status"] = node.NodeStatus
+		tfMap["label"] = node.Label
+		tfMap["model_number"] = node.ModelNumber
+		tfMap["serial_number"] = node.SerialNumber
+		tfMap["mac_address"] = node.MacAddress
+
+		for _, nodeUptime := range nodeUptimes {
+			if nodeUptime.Id == node.Id {
+				tfMap["uptime"] = nodeUptime.Uptime
+			}
+		}
+
+		var ipAddresses []string
+		var floatingIpAddresses []string
+		for _, nodeStatus := range nodeStatuses {
+			if nodeStatus.NodeId != node.Id {
+				continue
+			}
+			for _, networkStatus := range nodeStatus.NetworkStatuses {
+				if networkStatus.Address != "" {
+					ipAddresses = append(ipAddresses, networkStatus.Address)
+				}
+				floatingIpAddresses = append(floatingIpAddresses, networkStatus.FloatingAddresses...)
+			}
+		}
+		tfMap["ip_addresses"] = ipAddresses
+		tfMap["floating_ip_addresses"] = floatingIpAddresses
+
+		var slotList []interface{}
+		for _, slot := range slots {
+			if slot.NodeId != node.Id {
+				continue
+			}
+			slotMap := map[string]interface{}{}
+			slotMap["id"] = slot.Id
+			slotMap["slot"] = slot.Slot
+			slotMap["state"] = slot.State
+			slotMap["slot_type"] = slot.SlotType
+			slotMap["disk_type"] = slot.DiskType
+			slotMap["disk_model"] = slot.DiskModel
+			slotMap["disk_serial_number"] = slot.DiskSerialNumber
+			slotMap["capacity"] = slot.Capacity
+			slotMap["high_endurance"] = slot.HighEndurance
+			slotMap["led_pattern"] = slot.LedPattern
+			slotList = append(slotList, slotMap)
+		}
+		tfMap["slots"] = slotList
+
+		tfList = append(tfList, tfMap)
+	}
+	return tfList
+}
@@ -0,0 +1,62 @@
+package qumulo
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccReadNodes(t *testing.T) {
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheckRealCluster(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNodesConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.qumulo_nodes.nodes", "degraded"),
+					testAccCheckNodesDataSource(),
+				),
+			},
+		},
+	})
+}
+
+const testAccNodesConfig = `
+data "qumulo_nodes" "nodes" {}
+`
+
+func testAccCheckNodesDataSource() resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		nodes, ok := s.RootModule().Resources["data.qumulo_nodes.nodes"]
+		if !ok {
+			return fmt.Errorf("nodes data source not found")
+		}
+
+		c := testAccProvider.Meta().(*Client)
+		ctx := context.Background()
+
+		remoteNodes, err := DoRequest[ClusterNodesEmptyBody, []ClusterNode](ctx, c, GET, ClusterNodesEndpoint, nil)
+		if err != nil {
+			return err
+		}
+
+		if v := nodes.Primary.Attributes["nodes.#"]; v != strconv.Itoa(len(*remoteNodes)) {
+			return fmt.Errorf("node count mismatch: Expected %v, got %v", len(*remoteNodes), v)
+		}
+		for i, node := range *remoteNodes {
+			if v := nodes.Primary.Attributes[fmt.Sprintf("nodes.%d.uuid", i)]; v != node.Uuid {
+				return fmt.Errorf("node UUID mismatch: Expected %v, got %v", node.Uuid, v)
+			}
+			if v := nodes.Primary.Attributes[fmt.Sprintf("nodes.%d.serial_number", i)]; v != node.SerialNumber {
+				return fmt.Errorf("node serial number mismatch: Expected %v, got %v", node.SerialNumber, v)
+			}
+		}
+		return nil
+	}
+}
@@ -0,0 +1,155 @@
+package qumulo
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const SnapshotPoliciesEndpoint = "/v2/snapshots/policies/"
+
+type SnapshotPolicy struct {
+	Id                   int                    `json:"id"`
+	PolicyName           string                 `json:"policy_name"`
+	SnapshotNameTemplate string                 `json:"snapshot_name_template"`
+	SourceFileId         string                 `json:"source_file_id"`
+	Enabled              bool                   `json:"enabled"`
+	Schedule             SnapshotPolicySchedule `json:"schedule"`
+	LockKeyRef           *string                `json:"lock_key_ref"`
+}
+
+type SnapshotPolicySchedule struct {
+	ExpirationTimeToLive string `json:"expiration_time_to_live"`
+}
+
+type SnapshotPoliciesResponse struct {
+	Entries []SnapshotPolicy `json:"entries"`
+}
+
+func dataSourceSnapshotPolicies() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceSnapshotPoliciesRead,
+
+		Timeouts: &schema.ResourceTimeout{
+			Read: schema.DefaultTimeout(1 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"policies": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": &schema.Schema{
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"policy_name": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"snapshot_name_template": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"source_file_id": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"enabled": &schema.Schema{
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"expiration_time_to_live": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"locked": &schema.Schema{
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"lock_key_ref": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"snapshot_count": &schema.Schema{
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"capacity_used_bytes": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceSnapshotPoliciesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+
+	policies, err := DoRequest[SnapshotsEmptyBody, SnapshotPoliciesResponse](ctx, c, GET, SnapshotPoliciesEndpoint, nil)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Space consumed by a policy is the sum of the space consumed by the snapshots it created
+	snapshots, err := DoRequest[SnapshotsEmptyBody, SnapshotsResponse](ctx, c, GET, SnapshotsEndpoint, nil)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	capacityUsed, err := readSnapshotsCapacityUsed(ctx, c)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("policies", flattenSnapshotPolicies(policies.Entries, snapshots.Entries, capacityUsed)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(strconv.FormatInt(time.Now().Unix(), 10))
+
+	return nil
+}
+
+func flattenSnapshotPolicies(policies []SnapshotPolicy, snapshots []Snapshot, capacityUsed map[int]string) []interface{} {
+	var tfList []interface{}
+
+	for _, policy := range policies {
+		tfMap := map[string]interface{}{}
+
+		tfMap["id"] = policy.Id
+		tfMap["policy_name"] = policy.PolicyName
+		tfMap["snapshot_name_template"] = policy.SnapshotNameTemplate
+		tfMap["source_file_id"] = policy.SourceFileId
+		tfMap["enabled"] = policy.Enabled
+		tfMap["expiration_time_to_live"] = policy.Schedule.ExpirationTimeToLive
+		tfMap["locked"] = policy.LockKeyRef != nil && *policy.LockKeyRef != ""
+		if policy.LockKeyRef != nil {
+			tfMap["lock_key_ref"] = *policy.LockKeyRef
+		}
+
+		snapshotCount := 0
+		var policyCapacityUsed uint64
+		for _, snapshot := range snapshots {
+			if snapshot.PolicyId == nil || *snapshot.PolicyId != policy.Id {
+				continue
+			}
+			snapshotCount++
+			if v, err := strconv.ParseUint(capacityUsed[snapshot.Id], 10, 64); err == nil {
+				policyCapacityUsed += v
+			}
+		}
+		tfMap["snapshot_count"] = snapshotCount
+		tfMap["capacity_used_bytes"] = strconv.FormatUint(policyCapacityUsed, 10)
+
+		tfList = append(tfList, tfMap)
+	}
+	return tfList
+}
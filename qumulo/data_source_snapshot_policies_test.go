@@ -0,0 +1,56 @@
+package qumulo
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccReadSnapshotPolicies(t *testing.T) {
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheckRealCluster(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSnapshotPoliciesConfig,
+				Check:  testAccCheckSnapshotPoliciesDataSource(),
+			},
+		},
+	})
+}
+
+const testAccSnapshotPoliciesConfig = `
+data "qumulo_snapshot_policies" "policies" {}
+`
+
+func testAccCheckSnapshotPoliciesDataSource() resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		policies, ok := s.RootModule().Resources["data.qumulo_snapshot_policies.policies"]
+		if !ok {
+			return fmt.Errorf("snapshot policies data source not found")
+		}
+
+		c := testAccProvider.Meta().(*Client)
+		ctx := context.Background()
+
+		remotePolicies, err := DoRequest[SnapshotsEmptyBody, SnapshotPoliciesResponse](ctx, c, GET, SnapshotPoliciesEndpoint, nil)
+		if err != nil {
+			return err
+		}
+
+		if v := policies.Primary.Attributes["policies.#"]; v != strconv.Itoa(len(remotePolicies.Entries)) {
+			return fmt.Errorf("snapshot policy count mismatch: Expected %v, got %v", len(remotePolicies.Entries), v)
+		}
+		for i, policy := range remotePolicies.Entries {
+			if v := policies.Primary.Attributes[fmt.Sprintf("policies.%d.policy_name", i)]; v != policy.PolicyName {
+				return fmt.Errorf("snapshot policy name mismatch: Expected %v, got %v", policy.PolicyName, v)
+			}
+		}
+		return nil
+	}
+}
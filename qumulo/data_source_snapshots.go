@@ -0,0 +1,255 @@
+package qumulo
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const SnapshotsEndpoint = "/v2/snapshots/"
+const SnapshotsCapacityUsedEndpoint = "/v1/snapshots/capacity-used-per-snapshot/"
+const FilesEndpoint = "/v1/files/"
+const FileAttributesSuffix = "/info/attributes"
+
+type Snapshot struct {
+	Id              int     `json:"id"`
+	Name            string  `json:"name"`
+	Timestamp       string  `json:"timestamp"`
+	DirectoryName   string  `json:"directory_name"`
+	SourceFileId    string  `json:"source_file_id"`
+	CreatedByPolicy bool    `json:"created_by_policy"`
+	PolicyId        *int    `json:"policy_id"`
+	Expiration      string  `json:"expiration"`
+	InDelete        bool    `json:"in_delete"`
+	LockKey         *string `json:"lock_key"`
+}
+
+type SnapshotsResponse struct {
+	Entries []Snapshot `json:"entries"`
+}
+
+type SnapshotCapacityUsed struct {
+	Id                int    `json:"id"`
+	CapacityUsedBytes string `json:"capacity_used_bytes"`
+}
+
+type SnapshotsCapacityUsedResponse struct {
+	Entries []SnapshotCapacityUsed `json:"entries"`
+}
+
+type FileAttributesResponse struct {
+	Id   string `json:"id"`
+	Path string `json:"path"`
+}
+
+type SnapshotsEmptyBody struct{}
+
+func dataSourceSnapshots() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceSnapshotsRead,
+
+		Timeouts: &schema.ResourceTimeout{
+			Read: schema.DefaultTimeout(1 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"source_path": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"policy_id": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"created_after": &schema.Schema{
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IsRFC3339Time),
+			},
+			"snapshots": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": &schema.Schema{
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"name": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"timestamp": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"directory_name": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"source_file_id": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"created_by_policy": &schema.Schema{
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"policy_id": &schema.Schema{
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"expiration": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"in_delete": &schema.Schema{
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"locked": &schema.Schema{
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"lock_key": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"capacity_used_bytes": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceSnapshotsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+
+	snapshots, err := DoRequest[SnapshotsEmptyBody, SnapshotsResponse](ctx, c, GET, SnapshotsEndpoint, nil)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	sourceFileId := ""
+	if v, ok := d.GetOk("source_path"); ok {
+		sourceFileId, err = readFileIdByPath(ctx, c, v.(string))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	var createdAfter time.Time
+	if v, ok := d.GetOk("created_after"); ok {
+		createdAfter, err = time.Parse(time.RFC3339, v.(string))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	var filteredSnapshots []Snapshot
+	for _, snapshot := range snapshots.Entries {
+		if sourceFileId != "" && snapshot.SourceFileId != sourceFileId {
+			continue
+		}
+		if v, ok := d.GetOk("policy_id"); ok && (snapshot.PolicyId == nil || *snapshot.PolicyId != v.(int)) {
+			continue
+		}
+		if v, ok := d.GetOk("name"); ok && snapshot.Name != v.(string) {
+			continue
+		}
+		if !createdAfter.IsZero() {
+			timestamp, err := time.Parse(time.RFC3339Nano, snapshot.Timestamp)
+			if err != nil {
+				return diag.FromErr(fmt.Errorf("unable to parse timestamp of snapshot %d: %w", snapshot.Id, err))
+			}
+			if !timestamp.After(createdAfter) {
+				continue
+			}
+		}
+		filteredSnapshots = append(filteredSnapshots, snapshot)
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Found %d of %d snapshots matching filters", len(filteredSnapshots), len(snapshots.Entries)))
+
+	capacityUsed, err := readSnapshotsCapacityUsed(ctx, c)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("snapshots", flattenSnapshots(filteredSnapshots, capacityUsed)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(strconv.FormatInt(time.Now().Unix(), 10))
+
+	return nil
+}
+
+func readFileIdByPath(ctx context.Context, c *Client, path string) (string, error) {
+	readFileAttributesUri := FilesEndpoint + url.PathEscape(path) + FileAttributesSuffix
+
+	attributes, err := DoRequest[SnapshotsEmptyBody, FileAttributesResponse](ctx, c, GET, readFileAttributesUri, nil)
+	if err != nil {
+		return "", fmt.Errorf("unable to resolve path %q: %w", path, err)
+	}
+
+	return attributes.Id, nil
+}
+
+func readSnapshotsCapacityUsed(ctx context.Context, c *Client) (map[int]string, error) {
+	capacityUsedResponse, err := DoRequest[SnapshotsEmptyBody, SnapshotsCapacityUsedResponse](ctx, c, GET, SnapshotsCapacityUsedEndpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	capacityUsed := make(map[int]string, len(capacityUsedResponse.Entries))
+	for _, entry := range capacityUsedResponse.Entries {
+		capacityUsed[entry.Id] = entry.CapacityUsedBytes
+	}
+
+	return capacityUsed, nil
+}
+
+func flattenSnapshots(snapshots []Snapshot, capacityUsed map[int]string) []interface{} {
+	var tfList []interface{}
+
+	for _, snapshot := range snapshots {
+		tfMap := map[string]interface{}{}
+
+		tfMap["id"] = snapshot.Id
+		tfMap["name"] = snapshot.Name
+		tfMap["timestamp"] = snapshot.Timestamp
+		tfMap["directory_name"] = snapshot.DirectoryName
+		tfMap["source_file_id"] = snapshot.SourceFileId
+		tfMap["created_by_policy"] = snapshot.CreatedByPolicy
+		if snapshot.PolicyId != nil {
+			tfMap["policy_id"] = *snapshot.PolicyId
+		}
+		tfMap["expiration"] = snapshot.Expiration
+		tfMap["in_delete"] = snapshot.InDelete
+		tfMap["locked"] = snapshot.LockKey != nil && *snapshot.LockKey != ""
+		if snapshot.LockKey != nil {
+			tfMap["lock_key"] = *snapshot.LockKey
+		}
+		tfMap["capacity_used_bytes"] = capacityUsed[snapshot.Id]
+
+		tfList = append(tfList, tfMap)
+	}
+	return tfList
+}
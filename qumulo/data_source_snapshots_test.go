@@ -0,0 +1,68 @@
+package qumulo
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccReadSnapshots(t *testing.T) {
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheckRealCluster(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSnapshotsConfig("/"),
+				Check:  testAccCheckSnapshotsDataSource("/"),
+			},
+		},
+	})
+}
+
+func testAccSnapshotsConfig(sourcePath string) string {
+	return fmt.Sprintf(`
+	data "qumulo_snapshots" "snapshots" {
+		source_path = %q
+		created_after = "2000-01-01T00:00:00Z"
+	}
+  `, sourcePath)
+}
+
+func testAccCheckSnapshotsDataSource(sourcePath string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		snapshots, ok := s.RootModule().Resources["data.qumulo_snapshots.snapshots"]
+		if !ok {
+			return fmt.Errorf("snapshots data source not found")
+		}
+
+		c := testAccProvider.Meta().(*Client)
+		ctx := context.Background()
+
+		sourceFileId, err := readFileIdByPath(ctx, c, sourcePath)
+		if err != nil {
+			return err
+		}
+
+		remoteSnapshots, err := DoRequest[SnapshotsEmptyBody, SnapshotsResponse](ctx, c, GET, SnapshotsEndpoint, nil)
+		if err != nil {
+			return err
+		}
+
+		expectedCount := 0
+		for _, snapshot := range remoteSnapshots.Entries {
+			if snapshot.SourceFileId == sourceFileId {
+				expectedCount++
+			}
+		}
+
+		if v := snapshots.Primary.Attributes["snapshots.#"]; v != strconv.Itoa(expectedCount) {
+			return fmt.Errorf("snapshot count mismatch: Expected %v, got %v", expectedCount, v)
+		}
+		return nil
+	}
+}
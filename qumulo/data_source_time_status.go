@@ -0,0 +1,106 @@
+package qumulo
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const TimeStatusEndpoint = "/v1/time/status"
+
+type TimeStatusResponse struct {
+	Time      string  `json:"time"`
+	Timezone  string  `json:"timezone"`
+	NtpServer string  `json:"ntp_server"`
+	Offset    float64 `json:"offset"`
+	Synced    bool    `json:"synced"`
+}
+
+func dataSourceTimeStatus() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceTimeStatusRead,
+
+		Timeouts: &schema.ResourceTimeout{
+			Read: schema.DefaultTimeout(1 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"time": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"timezone": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"ntp_server": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"offset": &schema.Schema{
+				Type:     schema.TypeFloat,
+				Computed: true,
+			},
+			"synced": &schema.Schema{
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"use_ad_for_primary": &schema.Schema{
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"ntp_servers": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"local_skew_seconds": &schema.Schema{
+				Type:     schema.TypeFloat,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceTimeStatusRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+
+	var errs ErrorCollection
+
+	timeConfig, err := DoRequest[TimeConfigurationBody, TimeConfigurationBody](ctx, c, GET, TimeConfigurationEndpoint, nil)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	errs.addMaybeError(d.Set("use_ad_for_primary", timeConfig.UseAdForPrimary))
+	errs.addMaybeError(d.Set("ntp_servers", timeConfig.NtpServers))
+
+	timeStatus, err := DoRequest[TimeStatusResponse, TimeStatusResponse](ctx, c, GET, TimeStatusEndpoint, nil)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	errs.addMaybeError(d.Set("time", timeStatus.Time))
+	errs.addMaybeError(d.Set("timezone", timeStatus.Timezone))
+	errs.addMaybeError(d.Set("ntp_server", timeStatus.NtpServer))
+	errs.addMaybeError(d.Set("offset", timeStatus.Offset))
+	errs.addMaybeError(d.Set("synced", timeStatus.Synced))
+
+	// Skew between the cluster and the machine running Terraform; Kerberos (and so an AD join)
+	// fails when clocks drift more than a few minutes apart
+	clusterTime, err := time.Parse(time.RFC3339Nano, timeStatus.Time)
+	if err != nil {
+		return append(errs.diags, diag.FromErr(fmt.Errorf("unable to parse cluster time %q: %w", timeStatus.Time, err))...)
+	}
+	errs.addMaybeError(d.Set("local_skew_seconds", time.Until(clusterTime).Seconds()))
+
+	d.SetId(strconv.FormatInt(time.Now().Unix(), 10))
+
+	return errs.diags
+}
@@ -0,0 +1,68 @@
+package qumulo
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccReadTimeStatus(t *testing.T) {
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccTimeConfigurationConfig(defaultTimeConfiguration) + testAccTimeStatusConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.qumulo_time_status.status", "time"),
+					resource.TestCheckResourceAttr("data.qumulo_time_status.status", "use_ad_for_primary",
+						fmt.Sprintf("%v", defaultTimeConfiguration.UseAdForPrimary)),
+					testAccCheckTimeStatusDataSource(),
+				),
+			},
+		},
+	})
+}
+
+const testAccTimeStatusConfig = `
+data "qumulo_time_status" "status" {
+	depends_on = [qumulo_time_configuration.time_config]
+}
+`
+
+func testAccCheckTimeStatusDataSource() resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		status, ok := s.RootModule().Resources["data.qumulo_time_status.status"]
+		if !ok {
+			return fmt.Errorf("time status data source not found")
+		}
+
+		c := testAccProvider.Meta().(*Client)
+		ctx := context.Background()
+
+		timeStatus, err := DoRequest[TimeStatusResponse, TimeStatusResponse](ctx, c, GET, TimeStatusEndpoint, nil)
+		if err != nil {
+			return err
+		}
+
+		if v := status.Primary.Attributes["ntp_server"]; v != timeStatus.NtpServer {
+			return fmt.Errorf("NTP server mismatch: Expected %v, got %v", timeStatus.NtpServer, v)
+		}
+
+		skew, err := strconv.ParseFloat(status.Primary.Attributes["local_skew_seconds"], 64)
+		if err != nil {
+			return err
+		}
+		// The test cluster is expected to be synced; allow for slow test runners
+		if math.Abs(skew) > 300 {
+			return fmt.Errorf("cluster time is %v seconds away from local time", skew)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,631 @@
+package qumulo
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+type DriftKind int
+
+const (
+	// An object on the cluster which isn't in the configuration
+	DriftUnmanaged DriftKind = iota + 1
+	// A resource in the configuration whose object isn't on the cluster
+	DriftMissing
+	// An attribute whose value on the cluster differs from the configuration
+	DriftChanged
+)
+
+func (k DriftKind) String() string {
+	return [...]string{"unmanaged", "missing", "changed"}[k-1]
+}
+
+func (k DriftKind) Heading() string {
+	return [...]string{"Unmanaged objects", "Missing objects", "Changed attributes"}[k-1]
+}
+
+func (k DriftKind) MarshalJSON() ([]byte, error) {
+	return json.Marshal(k.String())
+}
+
+type DriftFinding struct {
+	Kind         DriftKind `json:"kind"`
+	ResourceType string    `json:"resource_type"`
+	// The resource address in the configuration, unless the object is unmanaged
+	Address string `json:"address,omitempty"`
+	// The ID `terraform import` takes for the object
+	Id        string `json:"id,omitempty"`
+	Name      string `json:"name,omitempty"`
+	Attribute string `json:"attribute,omitempty"`
+	Expected  string `json:"expected,omitempty"`
+	Actual    string `json:"actual,omitempty"`
+}
+
+type DriftReport struct {
+	Cluster string `json:"cluster"`
+	// The addresses of the resources in the configuration which were compared to the cluster
+	Checked  []string       `json:"checked"`
+	Findings []DriftFinding `json:"findings"`
+}
+
+func (r *DriftReport) Count(kind DriftKind) int {
+	count := 0
+	for _, f := range r.Findings {
+		if f.Kind == kind {
+			count++
+		}
+	}
+	return count
+}
+
+// The subset of `terraform show -json` output the drift report needs, which is the same for a saved plan
+// (planned_values) and for the state (values)
+type TerraformShowOutput struct {
+	Values        *TerraformValues `json:"values"`
+	PlannedValues *TerraformValues `json:"planned_values"`
+}
+
+type TerraformValues struct {
+	RootModule TerraformModule `json:"root_module"`
+}
+
+type TerraformModule struct {
+	Resources    []TerraformResource `json:"resources"`
+	ChildModules []TerraformModule   `json:"child_modules"`
+}
+
+type TerraformResource struct {
+	Address string                 `json:"address"`
+	Mode    string                 `json:"mode"`
+	Type    string                 `json:"type"`
+	Values  map[string]interface{} `json:"values"`
+}
+
+type driftOptions struct {
+	clusterOptions
+	PlanFile         string
+	Format           string
+	OutputFile       string
+	Resources        []string
+	DetailedExitCode bool
+}
+
+var driftFormats = map[string]func(w io.Writer, report *DriftReport) error{
+	"text":  writeDriftText,
+	"json":  writeDriftJSON,
+	"junit": writeDriftJUnit,
+}
+
+// Runs `terraform-provider-qumulo drift`, which compares the objects on the cluster to the output of
+// `terraform show -json`. Returns the exit status: 0 when the report was written, or with
+// -detailed-exitcode 0 when nothing drifted and 2 when something did.
+func RunDrift(ctx context.Context, args []string, stdout, stderr io.Writer) int {
+	var opts driftOptions
+	var resources string
+
+	flags := flag.NewFlagSet("drift", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprint(stderr, "Usage: terraform-provider-qumulo drift -plan FILE [options]\n\n"+
+			"Reports the differences between a cluster and the output of `terraform show -json` for a saved plan or\n"+
+			"the state: objects which Terraform doesn't manage, resources whose objects are missing, and attributes\n"+
+			"which changed outside of Terraform.\n\n")
+		flags.PrintDefaults()
+	}
+	opts.addFlags(flags)
+	flags.StringVar(&opts.PlanFile, "plan", "", "output of `terraform show -json`, or - to read it from stdin")
+	flags.StringVar(&opts.Format, "format", "text", "report format: text, json or junit")
+	flags.StringVar(&opts.OutputFile, "output", "", "file to write the report to, defaults to stdout")
+	flags.StringVar(&resources, "resources", "", "comma separated resource types to compare, defaults to all of them")
+	flags.BoolVar(&opts.DetailedExitCode, "detailed-exitcode", false, "exit with status 2 when drift is found")
+
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+	if resources != "" {
+		opts.Resources = strings.Split(resources, ",")
+	}
+	if err := opts.validate(); err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 1
+	}
+	if opts.PlanFile == "" {
+		fmt.Fprintln(stderr, "Error: the output of `terraform show -json` must be given with -plan")
+		return 1
+	}
+	write, ok := driftFormats[opts.Format]
+	if !ok {
+		fmt.Fprintf(stderr, "Error: unknown report format %q\n", opts.Format)
+		return 1
+	}
+
+	var desired []TerraformResource
+	var err error
+	if opts.PlanFile == "-" {
+		desired, err = loadTerraformResources(os.Stdin)
+	} else {
+		desired, err = loadTerraformResourcesFile(opts.PlanFile)
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	report, err := driftReport(ctx, opts, desired, stderr)
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	out := stdout
+	if opts.OutputFile != "" {
+		f, err := os.Create(opts.OutputFile)
+		if err != nil {
+			fmt.Fprintf(stderr, "Error: %v\n", err)
+			return 1
+		}
+		defer f.Close()
+		out = f
+	}
+	if err := write(out, report); err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	if opts.DetailedExitCode && len(report.Findings) > 0 {
+		return 2
+	}
+	return 0
+}
+
+func loadTerraformResourcesFile(path string) ([]TerraformResource, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return loadTerraformResources(f)
+}
+
+// Returns the qumulo resources managed by the root module and its child modules
+func loadTerraformResources(r io.Reader) ([]TerraformResource, error) {
+	decoder := json.NewDecoder(r)
+	// Keeps large numbers, such as quota limits, exact
+	decoder.UseNumber()
+
+	var output TerraformShowOutput
+	if err := decoder.Decode(&output); err != nil {
+		return nil, fmt.Errorf("parsing the output of `terraform show -json`: %w", err)
+	}
+
+	values := output.PlannedValues
+	if values == nil {
+		values = output.Values
+	}
+	if values == nil {
+		return nil, fmt.Errorf("the output of `terraform show -json` has neither planned_values nor values")
+	}
+
+	var resources []TerraformResource
+	var collect func(m TerraformModule)
+	collect = func(m TerraformModule) {
+		for _, r := range m.Resources {
+			if r.Mode == "managed" && strings.HasPrefix(r.Type, "qumulo_") {
+				resources = append(resources, r)
+			}
+		}
+		for _, child := range m.ChildModules {
+			collect(child)
+		}
+	}
+	collect(values.RootModule)
+
+	return resources, nil
+}
+
+// The ID `terraform import` takes for a resource. Networks are stored by network ID, but imported
+// together with their interface.
+func terraformImportId(r TerraformResource) string {
+	id, _ := r.Values["id"].(string)
+	if id != "" && r.Type == "qumulo_network_configuration" {
+		if interfaceId, ok := r.Values["interface_id"].(string); ok {
+			return interfaceId + ":" + id
+		}
+	}
+	return id
+}
+
+func driftReport(ctx context.Context, opts driftOptions, desired []TerraformResource, stderr io.Writer) (*DriftReport, error) {
+	for _, resourceType := range opts.Resources {
+		if _, ok := findExporter(resourceType); !ok {
+			return nil, fmt.Errorf("resource type %q can't be compared", resourceType)
+		}
+	}
+
+	c, err := opts.client(ctx)
+	if err != nil {
+		return nil, err
+	}
+	clusterUuid, err := readClusterUuid(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &DriftReport{Cluster: opts.Host}
+
+	for _, e := range exporters {
+		if len(opts.Resources) > 0 && !StringSliceContains(opts.Resources, e.ResourceType) {
+			continue
+		}
+
+		managed := map[string]TerraformResource{}
+		for _, r := range desired {
+			if r.Type != e.ResourceType {
+				continue
+			}
+			id := terraformImportId(r)
+			if id == "" {
+				// The resource hasn't been created yet
+				report.Findings = append(report.Findings, DriftFinding{
+					Kind:         DriftMissing,
+					ResourceType: r.Type,
+					Address:      r.Address,
+				})
+				continue
+			}
+			managed[id] = r
+		}
+
+		objects, err := e.List(ctx, c)
+		if err != nil {
+			// Resource types the cluster doesn't support can't drift unless they are managed
+			if len(managed) > 0 {
+				return nil, fmt.Errorf("listing %s: %w", e.ResourceType, err)
+			}
+			fmt.Fprintf(stderr, "Warning: skipping %s: %v\n", e.ResourceType, err)
+			continue
+		}
+
+		r, err := newExportedResource(ctx, e.ResourceType)
+		if err != nil {
+			return nil, err
+		}
+		for _, object := range objects {
+			resource, ok := managed[object.Id]
+			if !ok {
+				// Settings and built-in objects exist on every cluster whether or not they are managed
+				if object.Id != clusterUuid && !object.BuiltIn {
+					report.Findings = append(report.Findings, DriftFinding{
+						Kind:         DriftUnmanaged,
+						ResourceType: e.ResourceType,
+						Id:           object.Id,
+						Name:         object.Label,
+					})
+				}
+				continue
+			}
+			delete(managed, object.Id)
+
+			actual, err := r.Read(ctx, c, object.Id)
+			if err != nil {
+				return nil, fmt.Errorf("reading %s: %w", resource.Address, err)
+			}
+			report.Checked = append(report.Checked, resource.Address)
+			report.Findings = append(report.Findings, driftedAttributes(r.Schema, resource, object, actual)...)
+		}
+
+		var missing []string
+		for id := range managed {
+			missing = append(missing, id)
+		}
+		sort.Strings(missing)
+		for _, id := range missing {
+			report.Findings = append(report.Findings, DriftFinding{
+				Kind:         DriftMissing,
+				ResourceType: e.ResourceType,
+				Address:      managed[id].Address,
+				Id:           id,
+			})
+		}
+	}
+
+	return report, nil
+}
+
+// Compares the attributes a user configures, leaving out computed and secret attributes, the ones which
+// aren't known until apply, and the ones the Read function doesn't set
+func driftedAttributes(s map[string]*schema.Schema, desired TerraformResource, object exportedObject, actual map[string]interface{}) []DriftFinding {
+	var keys []string
+	for k := range s {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var findings []DriftFinding
+	for _, k := range keys {
+		if s[k].Computed && !s[k].Optional && !s[k].Required {
+			continue
+		}
+		if s[k].Sensitive {
+			continue
+		}
+		actualValue, ok := actual[k]
+		if !ok || driftValueEqual(s[k], desired.Values[k], actualValue) {
+			continue
+		}
+
+		findings = append(findings, DriftFinding{
+			Kind:         DriftChanged,
+			ResourceType: desired.Type,
+			Address:      desired.Address,
+			Id:           object.Id,
+			Name:         object.Label,
+			Attribute:    k,
+			Expected:     driftString(desired.Values[k]),
+			Actual:       driftString(actualValue),
+		})
+	}
+	return findings
+}
+
+// Unknown and unset values in the configuration match anything. Nested blocks only compare the attributes
+// which are set in the configuration, and sets are compared regardless of order.
+func driftValueEqual(s *schema.Schema, desired, actual interface{}) bool {
+	if desired == nil {
+		return true
+	}
+
+	switch s.Type {
+	case schema.TypeList, schema.TypeSet:
+		desiredElements, ok := desired.([]interface{})
+		actualElements := exportedElements(actual)
+		if !ok || len(desiredElements) != len(actualElements) {
+			return false
+		}
+
+		elementEqual := func(desired, actual interface{}) bool {
+			switch elem := s.Elem.(type) {
+			case *schema.Resource:
+				return driftObjectEqual(elem.Schema, desired, actual)
+			case *schema.Schema:
+				return driftValueEqual(elem, desired, actual)
+			}
+			return driftString(desired) == driftString(actual)
+		}
+
+		if s.Type == schema.TypeList {
+			for i := range desiredElements {
+				if !elementEqual(desiredElements[i], actualElements[i]) {
+					return false
+				}
+			}
+			return true
+		}
+
+		matched := make([]bool, len(actualElements))
+		for _, desiredElement := range desiredElements {
+			found := false
+			for i, actualElement := range actualElements {
+				if !matched[i] && elementEqual(desiredElement, actualElement) {
+					matched[i] = true
+					found = true
+					break
+				}
+			}
+			if !found {
+				return false
+			}
+		}
+		return true
+	case schema.TypeMap:
+		desiredMap, ok := desired.(map[string]interface{})
+		actualMap, _ := actual.(map[string]interface{})
+		if !ok || len(desiredMap) != len(actualMap) {
+			return false
+		}
+		for k, v := range desiredMap {
+			if fmt.Sprint(v) != fmt.Sprint(actualMap[k]) {
+				return false
+			}
+		}
+		return true
+	default:
+		// Values which the resource considers equivalent, such as "15m" and "900s", haven't drifted
+		if s.DiffSuppressFunc != nil && s.DiffSuppressFunc("", fmt.Sprint(actual), fmt.Sprint(desired), nil) {
+			return true
+		}
+		return fmt.Sprint(desired) == fmt.Sprint(actual)
+	}
+}
+
+func driftObjectEqual(s map[string]*schema.Schema, desired, actual interface{}) bool {
+	desiredMap, ok := desired.(map[string]interface{})
+	if !ok {
+		return false
+	}
+	actualMap, _ := actual.(map[string]interface{})
+
+	for k, v := range desiredMap {
+		if nested, ok := s[k]; ok && !nested.Sensitive && !driftValueEqual(nested, v, actualMap[k]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Formats a value from the configuration or the cluster as JSON
+func driftString(v interface{}) string {
+	b, err := json.Marshal(driftJSONValue(v))
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(b)
+}
+
+func driftJSONValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case *schema.Set:
+		return driftJSONValue(v.List())
+	case []interface{}:
+		values := make([]interface{}, len(v))
+		for i, element := range v {
+			values[i] = driftJSONValue(element)
+		}
+		return values
+	case map[string]interface{}:
+		values := map[string]interface{}{}
+		for k, element := range v {
+			values[k] = driftJSONValue(element)
+		}
+		return values
+	}
+	return v
+}
+
+func writeDriftText(w io.Writer, report *DriftReport) error {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "Drift report for %s\n", report.Cluster)
+
+	for _, kind := range []DriftKind{DriftUnmanaged, DriftMissing, DriftChanged} {
+		if report.Count(kind) == 0 {
+			continue
+		}
+
+		fmt.Fprintf(&b, "\n%s (%d):\n", kind.Heading(), report.Count(kind))
+		for _, f := range report.Findings {
+			if f.Kind != kind {
+				continue
+			}
+			switch kind {
+			case DriftUnmanaged:
+				fmt.Fprintf(&b, "  %s %q (id %s)\n", f.ResourceType, f.Name, f.Id)
+			case DriftMissing:
+				if f.Id == "" {
+					fmt.Fprintf(&b, "  %s (not yet created)\n", f.Address)
+				} else {
+					fmt.Fprintf(&b, "  %s (id %s)\n", f.Address, f.Id)
+				}
+			case DriftChanged:
+				fmt.Fprintf(&b, "  %s.%s: expected %s, got %s\n", f.Address, f.Attribute, f.Expected, f.Actual)
+			}
+		}
+	}
+
+	if len(report.Findings) == 0 {
+		fmt.Fprintf(&b, "\nNo drift found in %d resources.\n", len(report.Checked))
+	} else {
+		fmt.Fprintf(&b, "\nChecked %d resources: %d unmanaged objects, %d missing objects, %d changed attributes.\n",
+			len(report.Checked), report.Count(DriftUnmanaged), report.Count(DriftMissing), report.Count(DriftChanged))
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func writeDriftJSON(w io.Writer, report *DriftReport) error {
+	if report.Checked == nil {
+		report.Checked = []string{}
+	}
+	if report.Findings == nil {
+		report.Findings = []DriftFinding{}
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(report)
+}
+
+type JUnitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Suites   []JUnitTestSuite `xml:"testsuite"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+}
+
+type JUnitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Cases    []JUnitTestCase `xml:"testcase"`
+}
+
+type JUnitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *JUnitFailure `xml:"failure,omitempty"`
+}
+
+type JUnitFailure struct {
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// Writes a test case for each compared resource, failing when it changed, and a failing test case for
+// each missing or unmanaged object
+func writeDriftJUnit(w io.Writer, report *DriftReport) error {
+	suite := JUnitTestSuite{Name: "drift " + report.Cluster}
+
+	cases := map[string]*JUnitTestCase{}
+	var order []string
+	testCase := func(name, className string) *JUnitTestCase {
+		if _, ok := cases[name]; !ok {
+			cases[name] = &JUnitTestCase{Name: name, ClassName: className}
+			order = append(order, name)
+		}
+		return cases[name]
+	}
+	fail := func(tc *JUnitTestCase, message, text string) {
+		if tc.Failure == nil {
+			tc.Failure = &JUnitFailure{Message: message}
+		}
+		tc.Failure.Text += text + "\n"
+	}
+
+	for _, address := range report.Checked {
+		resourceType, _, _ := strings.Cut(address, ".")
+		testCase(address, resourceType)
+	}
+	for _, f := range report.Findings {
+		switch f.Kind {
+		case DriftUnmanaged:
+			tc := testCase(f.ResourceType+" "+f.Id, f.ResourceType)
+			fail(tc, "object isn't managed by Terraform", fmt.Sprintf("%s %q (id %s) isn't in the configuration", f.ResourceType, f.Name, f.Id))
+		case DriftMissing:
+			tc := testCase(f.Address, f.ResourceType)
+			fail(tc, "object is missing", fmt.Sprintf("%s isn't on the cluster", f.Address))
+		case DriftChanged:
+			tc := testCase(f.Address, f.ResourceType)
+			fail(tc, "attributes changed outside of Terraform", fmt.Sprintf("%s: expected %s, got %s", f.Attribute, f.Expected, f.Actual))
+		}
+	}
+
+	for _, name := range order {
+		suite.Cases = append(suite.Cases, *cases[name])
+		if cases[name].Failure != nil {
+			suite.Failures++
+		}
+	}
+	suite.Tests = len(suite.Cases)
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(JUnitTestSuites{Suites: []JUnitTestSuite{suite}, Tests: suite.Tests, Failures: suite.Failures}); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
@@ -0,0 +1,235 @@
+package qumulo
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestDriftReport(t *testing.T) {
+	f := newFakeCluster()
+	defer f.Close()
+
+	ctx := context.Background()
+	u, _ := url.Parse(f.URL)
+	opts := driftOptions{
+		clusterOptions: clusterOptions{
+			Host:     u.Hostname(),
+			Port:     u.Port(),
+			Username: fakeClusterUsername,
+			Password: fakeClusterPassword,
+		},
+		Resources: []string{"qumulo_cluster_name", "qumulo_local_user", "qumulo_smb_share", "qumulo_nfs_export"},
+	}
+
+	c, err := NewClient(ctx, &opts.Host, &opts.Port, &opts.Username, &opts.Password)
+	if err != nil {
+		t.Fatalf("unexpected error signing in to the fake cluster: %v", err)
+	}
+	create := func(uri string, body interface{}) map[string]interface{} {
+		created, err := DoRequest[interface{}, map[string]interface{}](ctx, c, POST, uri, &body)
+		if err != nil {
+			t.Fatalf("unexpected error creating %s: %v", uri, err)
+		}
+		return *created
+	}
+	user := create(UsersEndpoint, UserBody{Name: "jane", PrimaryGroup: "513"})
+	unmanaged := create(UsersEndpoint, UserBody{Name: "joe", PrimaryGroup: "513"})
+	share := create(SmbSharesEndpoint, SmbShare{ShareName: "Files", FsPath: "/", Description: "Edited by hand", Permissions: []SmbPermission{{
+		Type:    "ALLOWED",
+		Trustee: SmbTrustee{Name: "jane"},
+		Rights:  []string{"READ", "WRITE"},
+	}}})
+
+	show := `{
+	  "format_version": "1.1",
+	  "planned_values": {
+	    "root_module": {
+	      "resources": [
+	        {"address": "qumulo_cluster_name.cluster", "mode": "managed", "type": "qumulo_cluster_name",
+	         "values": {"id": "` + fakeClusterUuid + `", "cluster_name": "fake-cluster"}},
+	        {"address": "qumulo_local_user.jane", "mode": "managed", "type": "qumulo_local_user",
+	         "values": {"id": "` + user["id"].(string) + `", "name": "jane", "primary_group": "513", "password": null}},
+	        {"address": "data.qumulo_local_user.jane", "mode": "data", "type": "qumulo_local_user",
+	         "values": {"id": "1"}},
+	        {"address": "qumulo_nfs_export.new", "mode": "managed", "type": "qumulo_nfs_export",
+	         "values": {"export_path": "/new"}}
+	      ],
+	      "child_modules": [
+	        {"resources": [
+	          {"address": "module.shares.qumulo_smb_share.files", "mode": "managed", "type": "qumulo_smb_share",
+	           "values": {"id": "` + share["id"].(string) + `", "share_name": "Files", "fs_path": "/", "description": "Files",
+	                      "permissions": [{"type": "ALLOWED", "rights": ["READ", "WRITE"], "trustee": [{"name": "jane", "sid": null}]}]}},
+	          {"address": "module.shares.qumulo_smb_share.gone", "mode": "managed", "type": "qumulo_smb_share",
+	           "values": {"id": "1000", "share_name": "Gone"}}
+	        ]}
+	      ]
+	    }
+	  }
+	}`
+
+	desired, err := loadTerraformResources(strings.NewReader(show))
+	if err != nil {
+		t.Fatalf("unexpected error loading resources: %v", err)
+	}
+	if len(desired) != 5 {
+		t.Fatalf("expected the 5 managed resources to be loaded, got %d", len(desired))
+	}
+
+	var stderr bytes.Buffer
+	report, err := driftReport(ctx, opts, desired, &stderr)
+	if err != nil {
+		t.Fatalf("unexpected error comparing the cluster: %v", err)
+	}
+
+	expected := []DriftFinding{
+		{Kind: DriftUnmanaged, ResourceType: "qumulo_local_user", Id: unmanaged["id"].(string), Name: "joe"},
+		{Kind: DriftChanged, ResourceType: "qumulo_smb_share", Address: "module.shares.qumulo_smb_share.files", Id: share["id"].(string),
+			Name: "Files", Attribute: "description", Expected: `"Files"`, Actual: `"Edited by hand"`},
+		{Kind: DriftMissing, ResourceType: "qumulo_smb_share", Address: "module.shares.qumulo_smb_share.gone", Id: "1000"},
+		{Kind: DriftMissing, ResourceType: "qumulo_nfs_export", Address: "qumulo_nfs_export.new"},
+	}
+	if len(report.Findings) != len(expected) {
+		t.Fatalf("expected %d findings, got %+v", len(expected), report.Findings)
+	}
+	for i := range expected {
+		if report.Findings[i] != expected[i] {
+			t.Errorf("expected finding %d to be %+v, got %+v", i, expected[i], report.Findings[i])
+		}
+	}
+	if len(report.Checked) != 3 {
+		t.Errorf("expected 3 resources to be compared, got %v", report.Checked)
+	}
+
+	var text bytes.Buffer
+	if err := writeDriftText(&text, report); err != nil {
+		t.Fatalf("unexpected error writing the text report: %v", err)
+	}
+	for _, line := range []string{
+		`  qumulo_local_user "joe" (id ` + unmanaged["id"].(string) + `)`,
+		`  qumulo_nfs_export.new (not yet created)`,
+		`  module.shares.qumulo_smb_share.files.description: expected "Files", got "Edited by hand"`,
+		`Checked 3 resources: 1 unmanaged objects, 2 missing objects, 1 changed attributes.`,
+	} {
+		if !strings.Contains(text.String(), line) {
+			t.Errorf("expected the text report to contain %q:\n%s", line, text.String())
+		}
+	}
+
+	var jsonReport bytes.Buffer
+	if err := writeDriftJSON(&jsonReport, report); err != nil {
+		t.Fatalf("unexpected error writing the JSON report: %v", err)
+	}
+	var decoded map[string]interface{}
+	if err := json.Unmarshal(jsonReport.Bytes(), &decoded); err != nil {
+		t.Fatalf("the JSON report isn't valid JSON: %v", err)
+	}
+	if kind := decoded["findings"].([]interface{})[0].(map[string]interface{})["kind"]; kind != "unmanaged" {
+		t.Errorf("expected kinds to be written by name, got %v", kind)
+	}
+
+	var junit bytes.Buffer
+	if err := writeDriftJUnit(&junit, report); err != nil {
+		t.Fatalf("unexpected error writing the JUnit report: %v", err)
+	}
+	var suites JUnitTestSuites
+	if err := xml.Unmarshal(junit.Bytes(), &suites); err != nil {
+		t.Fatalf("the JUnit report isn't valid XML: %v", err)
+	}
+	// The cluster name and jane pass, the share which drifted, the missing objects and the unmanaged
+	// user fail
+	if suites.Tests != 6 || suites.Failures != 4 {
+		t.Errorf("expected 6 test cases with 4 failures, got %d with %d:\n%s", suites.Tests, suites.Failures, junit.String())
+	}
+}
+
+func TestDriftReportUnchanged(t *testing.T) {
+	f := newFakeCluster()
+	defer f.Close()
+
+	u, _ := url.Parse(f.URL)
+	opts := driftOptions{
+		clusterOptions: clusterOptions{
+			Host:     u.Hostname(),
+			Port:     u.Port(),
+			Username: fakeClusterUsername,
+			Password: fakeClusterPassword,
+		},
+		Resources: []string{"qumulo_cluster_name", "qumulo_role", "qumulo_web_ui"},
+	}
+	// The cluster has an inactivity timeout of 15 minutes
+	show := `{"values": {"root_module": {"resources": [
+	  {"address": "qumulo_cluster_name.cluster", "mode": "managed", "type": "qumulo_cluster_name",
+	   "values": {"id": "` + fakeClusterUuid + `", "cluster_name": "fake-cluster"}},
+	  {"address": "qumulo_web_ui.settings", "mode": "managed", "type": "qumulo_web_ui",
+	   "values": {"id": "` + fakeClusterUuid + `", "inactivity_timeout": "900s", "login_banner": ""}}
+	]}}}`
+
+	desired, err := loadTerraformResources(strings.NewReader(show))
+	if err != nil {
+		t.Fatalf("unexpected error loading resources: %v", err)
+	}
+	report, err := driftReport(context.Background(), opts, desired, &bytes.Buffer{})
+	if err != nil {
+		t.Fatalf("unexpected error comparing the cluster: %v", err)
+	}
+	if len(report.Findings) != 0 {
+		t.Errorf("expected no drift, got %+v", report.Findings)
+	}
+
+	var text bytes.Buffer
+	writeDriftText(&text, report)
+	if !strings.Contains(text.String(), "No drift found in 2 resources.") {
+		t.Errorf("expected the text report to say nothing drifted:\n%s", text.String())
+	}
+}
+
+func TestDriftReportBuiltIns(t *testing.T) {
+	f := newFakeCluster()
+	defer f.Close()
+	f.addBuiltIns()
+
+	u, _ := url.Parse(f.URL)
+	opts := driftOptions{
+		clusterOptions: clusterOptions{
+			Host:     u.Hostname(),
+			Port:     u.Port(),
+			Username: fakeClusterUsername,
+			Password: fakeClusterPassword,
+		},
+		Resources: []string{"qumulo_local_user", "qumulo_local_group", "qumulo_local_group_member", "qumulo_role",
+			"qumulo_role_member", "qumulo_smb_share", "qumulo_nfs_export"},
+	}
+
+	// A cluster with only the objects it came with hasn't drifted from an empty configuration
+	report, err := driftReport(context.Background(), opts, nil, &bytes.Buffer{})
+	if err != nil {
+		t.Fatalf("unexpected error comparing the cluster: %v", err)
+	}
+	if len(report.Findings) != 0 {
+		t.Errorf("expected no drift, got %+v", report.Findings)
+	}
+
+	// Built-in objects which are managed are still compared
+	show := `{"values": {"root_module": {"resources": [
+	  {"address": "qumulo_smb_share.files", "mode": "managed", "type": "qumulo_smb_share",
+	   "values": {"id": "1", "share_name": "Files", "fs_path": "/", "description": "All files"}}
+	]}}}`
+	desired, err := loadTerraformResources(strings.NewReader(show))
+	if err != nil {
+		t.Fatalf("unexpected error loading resources: %v", err)
+	}
+	report, err = driftReport(context.Background(), opts, desired, &bytes.Buffer{})
+	if err != nil {
+		t.Fatalf("unexpected error comparing the cluster: %v", err)
+	}
+	expected := DriftFinding{Kind: DriftChanged, ResourceType: "qumulo_smb_share", Address: "qumulo_smb_share.files", Id: "1",
+		Name: "Files", Attribute: "description", Expected: `"All files"`, Actual: `""`}
+	if len(report.Findings) != 1 || report.Findings[0] != expected {
+		t.Errorf("expected only %+v, got %+v", expected, report.Findings)
+	}
+}
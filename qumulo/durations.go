@@ -0,0 +1,75 @@
+package qumulo
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// The API gives durations as a string of nanoseconds
+func parseNanoseconds(v string) (time.Duration, error) {
+	ns, err := strconv.ParseInt(v, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("expected a number of nanoseconds, got %q", v)
+	}
+	return time.Duration(ns), nil
+}
+
+func formatNanoseconds(d time.Duration) string {
+	return strconv.FormatInt(d.Nanoseconds(), 10)
+}
+
+// Formats a duration the way it would be written in a configuration, such as "15m" rather than the
+// "15m0s" of time.Duration.String
+func formatDuration(d time.Duration) string {
+	s := d.String()
+	if strings.HasSuffix(s, "m0s") {
+		s = strings.TrimSuffix(s, "0s")
+	}
+	if strings.HasSuffix(s, "h0m") {
+		s = strings.TrimSuffix(s, "0m")
+	}
+	return s
+}
+
+func parseDurationNanoseconds(v string) (int64, error) {
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		return 0, err
+	}
+	return d.Nanoseconds(), nil
+}
+
+func validateDuration(i interface{}, k string) ([]string, []error) {
+	v, ok := i.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %s to be string", k)}
+	}
+
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		return nil, []error{fmt.Errorf("expected %s to be a duration such as \"15m\" or \"1h30m\", got %q", k, v)}
+	}
+	if d < 0 {
+		return nil, []error{fmt.Errorf("expected %s to be a positive duration, got %q", k, v)}
+	}
+
+	return nil, nil
+}
+
+// Durations are equivalent when they are as long, such as "15m" and "900s"
+func suppressEquivalentDuration(k, old, new string, d *schema.ResourceData) bool {
+	oldDuration, err := time.ParseDuration(old)
+	if err != nil {
+		return false
+	}
+	newDuration, err := time.ParseDuration(new)
+	if err != nil {
+		return false
+	}
+
+	return oldDuration == newDuration
+}
@@ -0,0 +1,49 @@
+package qumulo
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFormatDuration(t *testing.T) {
+	for _, tc := range []struct {
+		d        time.Duration
+		expected string
+	}{
+		{15 * time.Minute, "15m"},
+		{time.Hour, "1h"},
+		{90 * time.Minute, "1h30m"},
+		{90 * time.Second, "1m30s"},
+		{30 * time.Second, "30s"},
+		{0, "0s"},
+	} {
+		if got := formatDuration(tc.d); got != tc.expected {
+			t.Errorf("expected %v to be formatted as %q, got %q", tc.d, tc.expected, got)
+		}
+	}
+}
+
+func TestValidateDuration(t *testing.T) {
+	for _, v := range []string{"15m", "900s", "1h30m"} {
+		if _, errs := validateDuration(v, "inactivity_timeout"); len(errs) > 0 {
+			t.Errorf("unexpected error validating %q: %v", v, errs)
+		}
+	}
+	for _, v := range []string{"", "15", "fifteen minutes", "-1m"} {
+		if _, errs := validateDuration(v, "inactivity_timeout"); len(errs) == 0 {
+			t.Errorf("expected an error validating %q", v)
+		}
+	}
+}
+
+func TestSuppressEquivalentDuration(t *testing.T) {
+	if !suppressEquivalentDuration("inactivity_timeout", "15m", "900s", nil) {
+		t.Errorf("expected 15m and 900s to be equivalent")
+	}
+	if suppressEquivalentDuration("inactivity_timeout", "15m", "16m", nil) {
+		t.Errorf("expected 15m and 16m to differ")
+	}
+	if suppressEquivalentDuration("inactivity_timeout", "", "15m", nil) {
+		t.Errorf("expected an unset duration to differ")
+	}
+}
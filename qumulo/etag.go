@@ -0,0 +1,22 @@
+package qumulo
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Settings are refreshed and updated by different provider processes, so the ETag seen by the refresh
+// is kept in state and handed back to the client before updating
+func etagSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeString,
+		Computed: true,
+	}
+}
+
+func readETag(d *schema.ResourceData, m interface{}, endpointUri string) error {
+	return d.Set("etag", m.(*Client).ETag(endpointUri))
+}
+
+func expectETag(d *schema.ResourceData, m interface{}, endpointUri string) {
+	m.(*Client).SetETag(endpointUri, d.Get("etag").(string))
+}
@@ -0,0 +1,918 @@
+package qumulo
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	fwdiag "github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	rschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// Files written by `terraform-provider-qumulo export`
+const ExportResourcesFile = "qumulo_resources.tf"
+const ExportImportsFile = "qumulo_imports.tf"
+
+// Resource names are derived from object names, which may contain characters that aren't allowed in
+// Terraform identifiers
+var exportLabelRegexp = regexp.MustCompile(`[^a-z0-9_]+`)
+
+// An object on the cluster, identified by the ID `terraform import` takes for its resource type
+type exportedObject struct {
+	Id    string
+	Label string
+	// Objects every cluster comes with, such as the admin user, which aren't exported, and are only
+	// compared for drift when they are managed
+	BuiltIn bool
+}
+
+// Local users and groups every cluster comes with, by their well-known IDs
+var builtInUsers = map[string]string{"500": "admin", "501": "guest"}
+var builtInGroups = map[string]string{"512": "Administrators", "513": "Users", "514": "Guests"}
+
+var builtInRoles = []string{"Administrators", "Data-Administrators", "Observers"}
+
+// The share and the export of the root directory which clusters are created with
+const builtInSmbShareName = "Files"
+const builtInNfsExportPath = "/"
+
+func isBuiltInLocalName(name string) bool {
+	for _, builtIns := range []map[string]string{builtInUsers, builtInGroups} {
+		for _, builtIn := range builtIns {
+			if name == builtIn {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+type exporter struct {
+	ResourceType string
+	List         func(ctx context.Context, c *Client) ([]exportedObject, error)
+}
+
+// Resource types in the order they are written. qumulo_ssl_cert isn't exported, since the private key
+// of the certificate can't be read back from the cluster.
+var exporters = []exporter{
+	{"qumulo_cluster_name", listExportedSingleton},
+	{"qumulo_ad_settings", listExportedActiveDirectory},
+	{"qumulo_ldap_server", listExportedSingleton},
+	{"qumulo_ssl_ca", listExportedSingleton},
+	{"qumulo_monitoring", listExportedSingleton},
+	{"qumulo_time_configuration", listExportedSingleton},
+	{"qumulo_ftp_server", listExportedSingleton},
+	{"qumulo_file_system_settings", listExportedSingleton},
+	{"qumulo_syslog", listExportedSingleton},
+	{"qumulo_cloudwatch", listExportedSingleton},
+	{"qumulo_web_ui", listExportedSingleton},
+	{"qumulo_smb_server", listExportedSingleton},
+	{"qumulo_nfs_settings", listExportedSingleton},
+	{"qumulo_directory_quota", listExportedDirectoryQuotas},
+	{"qumulo_local_user", listExportedUsers},
+	{"qumulo_local_group", listExportedGroups},
+	{"qumulo_local_group_member", listExportedGroupMembers},
+	{"qumulo_role", listExportedRoles},
+	{"qumulo_role_member", listExportedRoleMembers},
+	{"qumulo_smb_share", listExportedSmbShares},
+	{"qumulo_nfs_export", listExportedNfsExports},
+	{"qumulo_interface_configuration", listExportedInterfaces},
+	{"qumulo_network_configuration", listExportedNetworks},
+}
+
+// How the subcommands of the provider binary reach the cluster
+type clusterOptions struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+}
+
+func (o *clusterOptions) addFlags(flags *flag.FlagSet) {
+	flags.StringVar(&o.Host, "host", os.Getenv("QUMULO_HOST"), "cluster address, defaults to $QUMULO_HOST")
+	flags.StringVar(&o.Port, "port", envOrDefault("QUMULO_PORT", "8000"), "REST API port, defaults to $QUMULO_PORT or 8000")
+	flags.StringVar(&o.Username, "username", os.Getenv("QUMULO_USERNAME"), "defaults to $QUMULO_USERNAME")
+	flags.StringVar(&o.Password, "password", os.Getenv("QUMULO_PASSWORD"), "defaults to $QUMULO_PASSWORD")
+}
+
+func (o *clusterOptions) validate() error {
+	if o.Host == "" {
+		return fmt.Errorf("the cluster address must be given with -host or $QUMULO_HOST")
+	}
+	return nil
+}
+
+// The commands only read from the cluster
+func (o *clusterOptions) client(ctx context.Context) (*Client, error) {
+	c, err := NewClient(ctx, &o.Host, &o.Port, &o.Username, &o.Password)
+	if err != nil {
+		return nil, err
+	}
+	c.ReadOnly = true
+
+	return c, nil
+}
+
+type exportOptions struct {
+	clusterOptions
+	OutputDir string
+	Resources []string
+}
+
+// Runs `terraform-provider-qumulo export`, which writes a resource block and a Terraform 1.5 import
+// block for every object on the cluster. Returns the exit status.
+func RunExport(ctx context.Context, args []string, stdout, stderr io.Writer) int {
+	var opts exportOptions
+	var resources string
+
+	flags := flag.NewFlagSet("export", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprintf(stderr, "Usage: terraform-provider-qumulo export [options]\n\n"+
+			"Writes %s and %s for the objects on a cluster, so that they can be brought under management with "+
+			"`terraform apply`. Secrets such as passwords are not exported.\n\n", ExportResourcesFile, ExportImportsFile)
+		flags.PrintDefaults()
+	}
+	opts.addFlags(flags)
+	flags.StringVar(&opts.OutputDir, "output", ".", "directory to write the configuration to")
+	flags.StringVar(&resources, "resources", "", "comma separated resource types to export, defaults to all of them")
+
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if resources != "" {
+		opts.Resources = strings.Split(resources, ",")
+	}
+	if err := opts.validate(); err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 2
+	}
+
+	if err := exportCluster(ctx, opts, stdout, stderr); err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+func envOrDefault(key, defaultValue string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return defaultValue
+}
+
+func exportCluster(ctx context.Context, opts exportOptions, stdout, stderr io.Writer) error {
+	for _, resourceType := range opts.Resources {
+		if _, ok := findExporter(resourceType); !ok {
+			return fmt.Errorf("resource type %q can't be exported", resourceType)
+		}
+	}
+
+	c, err := opts.client(ctx)
+	if err != nil {
+		return err
+	}
+
+	resourcesFile := hclwrite.NewEmptyFile()
+	importsFile := hclwrite.NewEmptyFile()
+	resourcesFile.Body().AppendUnstructuredTokens(exportComment(
+		"Generated by terraform-provider-qumulo export. Secrets such as passwords aren't exported and have to be\n" +
+			"added before applying, and attributes left at their defaults are omitted."))
+
+	labels := map[string]bool{}
+	failed := 0
+	exported := 0
+	skipped := 0
+
+	for _, e := range exporters {
+		if len(opts.Resources) > 0 && !StringSliceContains(opts.Resources, e.ResourceType) {
+			continue
+		}
+
+		objects, err := e.List(ctx, c)
+		if err != nil {
+			fmt.Fprintf(stderr, "Warning: skipping %s: %v\n", e.ResourceType, err)
+			failed++
+			continue
+		}
+
+		r, err := newExportedResource(ctx, e.ResourceType)
+		if err != nil {
+			return err
+		}
+		for _, object := range objects {
+			if object.BuiltIn {
+				skipped++
+				continue
+			}
+
+			values, err := r.Read(ctx, c, object.Id)
+			if err != nil {
+				fmt.Fprintf(stderr, "Warning: skipping %s %q: %v\n", e.ResourceType, object.Id, err)
+				failed++
+				continue
+			}
+
+			name := uniqueExportLabel(labels, e.ResourceType, object.Label)
+
+			resourcesFile.Body().AppendNewline()
+			block := resourcesFile.Body().AppendNewBlock("resource", []string{e.ResourceType, name})
+			writeExportedAttributes(block.Body(), r.Schema, values)
+
+			importsFile.Body().AppendNewline()
+			importBlock := importsFile.Body().AppendNewBlock("import", nil)
+			importBlock.Body().SetAttributeTraversal("to", hcl.Traversal{
+				hcl.TraverseRoot{Name: e.ResourceType},
+				hcl.TraverseAttr{Name: name},
+			})
+			importBlock.Body().SetAttributeValue("id", cty.StringVal(object.Id))
+
+			exported++
+		}
+	}
+
+	if err := os.MkdirAll(opts.OutputDir, 0755); err != nil {
+		return err
+	}
+	for file, f := range map[string]*hclwrite.File{ExportResourcesFile: resourcesFile, ExportImportsFile: importsFile} {
+		if err := os.WriteFile(filepath.Join(opts.OutputDir, file), f.Bytes(), 0644); err != nil {
+			return err
+		}
+	}
+
+	fmt.Fprintf(stdout, "Exported %d objects to %s and %s\n", exported, filepath.Join(opts.OutputDir, ExportResourcesFile),
+		filepath.Join(opts.OutputDir, ExportImportsFile))
+
+	if skipped > 0 {
+		fmt.Fprintf(stderr, "Skipped %d built-in objects, such as the admin user and the Files share\n", skipped)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d objects or resource types could not be exported", failed)
+	}
+	return nil
+}
+
+func findExporter(resourceType string) (exporter, bool) {
+	for _, e := range exporters {
+		if e.ResourceType == resourceType {
+			return e, true
+		}
+	}
+	return exporter{}, false
+}
+
+// How objects of a resource type are read, whether the resource has moved to the plugin framework or
+// not. Framework resources are described with the terraform-plugin-sdk schema types the other resources
+// use, and their values are given the way ResourceData.Get gives them.
+type exportedResource struct {
+	Schema map[string]*schema.Schema
+	Read   func(ctx context.Context, c *Client, id string) (map[string]interface{}, error)
+}
+
+func newExportedResource(ctx context.Context, resourceType string) (exportedResource, error) {
+	if newResource, ok := frameworkResources[resourceType]; ok {
+		return newFrameworkExportedResource(ctx, newResource)
+	}
+
+	r, ok := Provider().ResourcesMap[resourceType]
+	if !ok {
+		return exportedResource{}, fmt.Errorf("unknown resource type %q", resourceType)
+	}
+	return exportedResource{
+		Schema: r.Schema,
+		Read: func(ctx context.Context, c *Client, id string) (map[string]interface{}, error) {
+			d, err := readExportedResource(ctx, r, c, id)
+			if err != nil {
+				return nil, err
+			}
+			return resourceDataValues(r.Schema, d), nil
+		},
+	}, nil
+}
+
+// Reads an object the way `terraform import` does, by running the resource's importer and then its Read
+// function
+func readExportedResource(ctx context.Context, r *schema.Resource, c *Client, id string) (*schema.ResourceData, error) {
+	d := r.Data(nil)
+	d.SetId(id)
+
+	if r.Importer != nil {
+		var imported []*schema.ResourceData
+		var err error
+		if r.Importer.StateContext != nil {
+			imported, err = r.Importer.StateContext(ctx, d, c)
+		} else {
+			imported, err = r.Importer.State(d, c)
+		}
+		if err != nil {
+			return nil, err
+		}
+		d = imported[0]
+	}
+
+	for _, diagnostic := range r.ReadContext(ctx, d, c) {
+		if diagnostic.Severity == diag.Error {
+			return nil, fmt.Errorf("%s", diagnostic.Summary)
+		}
+	}
+	if d.Id() == "" {
+		return nil, fmt.Errorf("object no longer exists")
+	}
+
+	return d, nil
+}
+
+// Attributes which the Read function didn't set, such as on_destroy, are left out
+func resourceDataValues(s map[string]*schema.Schema, d *schema.ResourceData) map[string]interface{} {
+	attributes := d.State().Attributes
+
+	values := map[string]interface{}{}
+	for k := range s {
+		_, isSet := attributes[k]
+		_, isCollection := attributes[k+".#"]
+		_, isMap := attributes[k+".%"]
+		if isSet || isCollection || isMap {
+			values[k] = d.Get(k)
+		}
+	}
+	return values
+}
+
+func newFrameworkExportedResource(ctx context.Context, newResource func() resource.Resource) (exportedResource, error) {
+	var schemaResp resource.SchemaResponse
+	newResource().Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	if err := diagnosticsError(schemaResp.Diagnostics); err != nil {
+		return exportedResource{}, err
+	}
+
+	s := map[string]*schema.Schema{}
+	for k, attribute := range schemaResp.Schema.Attributes {
+		// id is computed, and the SDK resources leave it out of their schemas
+		if k == "id" {
+			continue
+		}
+		t, elem, err := exportedFrameworkType(attribute.GetType())
+		if err != nil {
+			return exportedResource{}, fmt.Errorf("attribute %s: %w", k, err)
+		}
+		s[k] = &schema.Schema{
+			Type:      t,
+			Elem:      elem,
+			Required:  attribute.IsRequired(),
+			Optional:  attribute.IsOptional(),
+			Computed:  attribute.IsComputed(),
+			Sensitive: attribute.IsSensitive(),
+		}
+	}
+	for k := range schemaResp.Schema.Blocks {
+		// The timeouts of the SDK resources aren't part of their schemas either
+		if k != "timeouts" {
+			return exportedResource{}, fmt.Errorf("block %s can't be exported", k)
+		}
+	}
+
+	return exportedResource{
+		Schema: s,
+		Read: func(ctx context.Context, c *Client, id string) (map[string]interface{}, error) {
+			return readFrameworkExportedResource(ctx, newResource(), schemaResp.Schema, c, id)
+		},
+	}, nil
+}
+
+func exportedFrameworkType(t attr.Type) (schema.ValueType, interface{}, error) {
+	switch t := t.(type) {
+	case basetypes.StringType:
+		return schema.TypeString, nil, nil
+	case basetypes.BoolType:
+		return schema.TypeBool, nil, nil
+	case basetypes.Int64Type:
+		return schema.TypeInt, nil, nil
+	case basetypes.ListType:
+		elem, err := exportedFrameworkElem(t.ElemType)
+		return schema.TypeList, elem, err
+	case basetypes.SetType:
+		elem, err := exportedFrameworkElem(t.ElemType)
+		return schema.TypeSet, elem, err
+	case basetypes.MapType:
+		elem, err := exportedFrameworkElem(t.ElemType)
+		return schema.TypeMap, elem, err
+	}
+	return 0, nil, fmt.Errorf("type %s can't be exported", t)
+}
+
+// Collections of nested objects are blocks in the SDK resources, so only collections of primitives are
+// attributes
+func exportedFrameworkElem(t attr.Type) (*schema.Schema, error) {
+	elemType, elem, err := exportedFrameworkType(t)
+	if err != nil {
+		return nil, err
+	}
+	if elem != nil {
+		return nil, fmt.Errorf("type %s can't be exported", t)
+	}
+	return &schema.Schema{Type: elemType}, nil
+}
+
+// Reads an object the way `terraform import` does, by running the resource's ImportState and then its
+// Read method. Null values are left out, like the attributes an SDK Read function doesn't set.
+func readFrameworkExportedResource(ctx context.Context, r resource.Resource, s rschema.Schema, c *Client, id string) (map[string]interface{}, error) {
+	if configurable, ok := r.(resource.ResourceWithConfigure); ok {
+		var configureResp resource.ConfigureResponse
+		configurable.Configure(ctx, resource.ConfigureRequest{ProviderData: c}, &configureResp)
+		if err := diagnosticsError(configureResp.Diagnostics); err != nil {
+			return nil, err
+		}
+	}
+
+	importer, ok := r.(resource.ResourceWithImportState)
+	if !ok {
+		return nil, fmt.Errorf("the resource can't be imported")
+	}
+	importResp := resource.ImportStateResponse{
+		State: tfsdk.State{Schema: s, Raw: tftypes.NewValue(s.Type().TerraformType(ctx), nil)},
+	}
+	importer.ImportState(ctx, resource.ImportStateRequest{ID: id}, &importResp)
+	if err := diagnosticsError(importResp.Diagnostics); err != nil {
+		return nil, err
+	}
+
+	readResp := resource.ReadResponse{State: importResp.State}
+	r.Read(ctx, resource.ReadRequest{State: importResp.State}, &readResp)
+	if err := diagnosticsError(readResp.Diagnostics); err != nil {
+		return nil, err
+	}
+	if readResp.State.Raw.IsNull() {
+		return nil, fmt.Errorf("object no longer exists")
+	}
+
+	var attributes map[string]tftypes.Value
+	if err := readResp.State.Raw.As(&attributes); err != nil {
+		return nil, err
+	}
+	values := map[string]interface{}{}
+	for k, v := range attributes {
+		if _, ok := s.Attributes[k]; !ok || v.IsNull() {
+			continue
+		}
+		value, err := exportedFrameworkValue(v)
+		if err != nil {
+			return nil, fmt.Errorf("attribute %s: %w", k, err)
+		}
+		values[k] = value
+	}
+	return values, nil
+}
+
+func exportedFrameworkValue(v tftypes.Value) (interface{}, error) {
+	if v.IsNull() || !v.IsKnown() {
+		return nil, nil
+	}
+
+	switch {
+	case v.Type().Is(tftypes.String):
+		var s string
+		err := v.As(&s)
+		return s, err
+	case v.Type().Is(tftypes.Bool):
+		var b bool
+		err := v.As(&b)
+		return b, err
+	case v.Type().Is(tftypes.Number):
+		var f big.Float
+		if err := v.As(&f); err != nil {
+			return nil, err
+		}
+		i, _ := f.Int64()
+		return int(i), nil
+	case v.Type().Is(tftypes.List{}), v.Type().Is(tftypes.Set{}):
+		var elements []tftypes.Value
+		if err := v.As(&elements); err != nil {
+			return nil, err
+		}
+		list := []interface{}{}
+		for _, element := range elements {
+			value, err := exportedFrameworkValue(element)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, value)
+		}
+		return list, nil
+	case v.Type().Is(tftypes.Map{}):
+		var elements map[string]tftypes.Value
+		if err := v.As(&elements); err != nil {
+			return nil, err
+		}
+		m := map[string]interface{}{}
+		for k, element := range elements {
+			value, err := exportedFrameworkValue(element)
+			if err != nil {
+				return nil, err
+			}
+			m[k] = value
+		}
+		return m, nil
+	}
+	return nil, fmt.Errorf("type %s can't be exported", v.Type())
+}
+
+// The first error, with the summary and detail of the diagnostic
+func diagnosticsError(diags fwdiag.Diagnostics) error {
+	if errs := diags.Errors(); len(errs) > 0 {
+		return fmt.Errorf("%s: %s", errs[0].Summary(), errs[0].Detail())
+	}
+	return nil
+}
+
+// Writes the attributes and nested blocks a user would configure, leaving out computed and secret
+// attributes and the ones at their defaults
+func writeExportedAttributes(body *hclwrite.Body, s map[string]*schema.Schema, values map[string]interface{}) {
+	var keys []string
+	for k := range s {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var blocks []string
+	for _, k := range keys {
+		if skipExportedAttribute(s[k], values[k]) {
+			continue
+		}
+		if _, ok := s[k].Elem.(*schema.Resource); ok {
+			blocks = append(blocks, k)
+			continue
+		}
+		body.SetAttributeValue(k, exportedValue(s[k], values[k]))
+	}
+
+	for _, k := range blocks {
+		nested := s[k].Elem.(*schema.Resource)
+		for _, element := range exportedElements(values[k]) {
+			if m, ok := element.(map[string]interface{}); ok {
+				writeExportedAttributes(body.AppendNewBlock(k, nil).Body(), nested.Schema, m)
+			}
+		}
+	}
+}
+
+func skipExportedAttribute(s *schema.Schema, v interface{}) bool {
+	if s.Computed && !s.Optional && !s.Required {
+		return true
+	}
+	if s.Sensitive || s.Deprecated != "" {
+		return true
+	}
+	if s.Required {
+		return false
+	}
+	if v == nil {
+		return true
+	}
+	if s.Default != nil {
+		return reflect.DeepEqual(v, s.Default)
+	}
+
+	return isZeroExportedValue(v)
+}
+
+func isZeroExportedValue(v interface{}) bool {
+	switch v := v.(type) {
+	case nil:
+		return true
+	case *schema.Set:
+		return v.Len() == 0
+	case []interface{}:
+		return len(v) == 0
+	case map[string]interface{}:
+		return len(v) == 0
+	default:
+		return reflect.ValueOf(v).IsZero()
+	}
+}
+
+func exportedElements(v interface{}) []interface{} {
+	switch v := v.(type) {
+	case *schema.Set:
+		return v.List()
+	case []interface{}:
+		return v
+	}
+	return nil
+}
+
+func exportedValue(s *schema.Schema, v interface{}) cty.Value {
+	switch s.Type {
+	case schema.TypeBool:
+		return cty.BoolVal(v.(bool))
+	case schema.TypeInt:
+		return cty.NumberIntVal(int64(v.(int)))
+	case schema.TypeFloat:
+		return cty.NumberFloatVal(v.(float64))
+	case schema.TypeList, schema.TypeSet:
+		elem, ok := s.Elem.(*schema.Schema)
+		if !ok {
+			elem = &schema.Schema{Type: schema.TypeString}
+		}
+
+		var values []cty.Value
+		for _, element := range exportedElements(v) {
+			values = append(values, exportedValue(elem, element))
+		}
+		if len(values) == 0 {
+			return cty.ListValEmpty(exportedType(elem))
+		}
+		return cty.ListVal(values)
+	case schema.TypeMap:
+		elem, ok := s.Elem.(*schema.Schema)
+		if !ok {
+			elem = &schema.Schema{Type: schema.TypeString}
+		}
+
+		values := map[string]cty.Value{}
+		for k, element := range v.(map[string]interface{}) {
+			values[k] = exportedValue(elem, element)
+		}
+		if len(values) == 0 {
+			return cty.MapValEmpty(exportedType(elem))
+		}
+		return cty.MapVal(values)
+	default:
+		return cty.StringVal(fmt.Sprint(v))
+	}
+}
+
+func exportedType(s *schema.Schema) cty.Type {
+	switch s.Type {
+	case schema.TypeBool:
+		return cty.Bool
+	case schema.TypeInt, schema.TypeFloat:
+		return cty.Number
+	default:
+		return cty.String
+	}
+}
+
+func exportComment(text string) hclwrite.Tokens {
+	var tokens hclwrite.Tokens
+	for _, line := range strings.Split(text, "\n") {
+		tokens = append(tokens, &hclwrite.Token{Type: hclsyntax.TokenComment, Bytes: []byte("# " + line + "\n")})
+	}
+	return tokens
+}
+
+// Turns an object name into a resource name which is unique within its resource type
+func uniqueExportLabel(labels map[string]bool, resourceType, name string) string {
+	label := strings.Trim(exportLabelRegexp.ReplaceAllString(strings.ToLower(name), "_"), "_")
+	if label == "" || (label[0] >= '0' && label[0] <= '9') {
+		label = "_" + label
+	}
+
+	unique := label
+	for i := 2; labels[resourceType+"."+unique]; i++ {
+		unique = label + "_" + strconv.Itoa(i)
+	}
+	labels[resourceType+"."+unique] = true
+
+	return unique
+}
+
+// Singletons are imported by cluster UUID
+func listExportedSingleton(ctx context.Context, c *Client) ([]exportedObject, error) {
+	clusterUuid, err := readClusterUuid(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+
+	return []exportedObject{{Id: clusterUuid, Label: "cluster"}}, nil
+}
+
+func listExportedActiveDirectory(ctx context.Context, c *Client) ([]exportedObject, error) {
+	status, err := DoRequest[ActiveDirectoryStatusBody, ActiveDirectoryStatusBody](ctx, c, GET, AdStatusEndpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	if status.Status != "JOINED_TO_DOMAIN" {
+		return nil, nil
+	}
+
+	return listExportedSingleton(ctx, c)
+}
+
+func listExportedDirectoryQuotas(ctx context.Context, c *Client) ([]exportedObject, error) {
+	var objects []exportedObject
+
+	nextUri := DirectoryQuotaStatusEndpoint
+	for nextUri != "" {
+		quotaPage, err := DoRequest[DirectoryQuotaEmptyBody, DirectoryQuotaStatusListResponse](ctx, c, GET, nextUri, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, quota := range quotaPage.Quotas {
+			objects = append(objects, exportedObject{Id: quota.Id, Label: "quota_" + quota.Path})
+		}
+		nextUri = quotaPage.Paging.Next
+	}
+
+	return objects, nil
+}
+
+func listExportedUsers(ctx context.Context, c *Client) ([]exportedObject, error) {
+	users, err := DoRequest[UserBody, []UserBody](ctx, c, GET, UsersEndpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var objects []exportedObject
+	for _, user := range *users {
+		_, builtIn := builtInUsers[user.Id]
+		objects = append(objects, exportedObject{Id: user.Id, Label: user.Name, BuiltIn: builtIn})
+	}
+	return objects, nil
+}
+
+func listExportedGroups(ctx context.Context, c *Client) ([]exportedObject, error) {
+	groups, err := DoRequest[GroupResponse, []GroupResponse](ctx, c, GET, GroupsEndpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var objects []exportedObject
+	for _, group := range *groups {
+		_, builtIn := builtInGroups[group.Id]
+		objects = append(objects, exportedObject{Id: group.Id, Label: group.Name, BuiltIn: builtIn})
+	}
+	return objects, nil
+}
+
+// Group members are imported as {group_id}:{member_id}
+func listExportedGroupMembers(ctx context.Context, c *Client) ([]exportedObject, error) {
+	groups, err := DoRequest[GroupResponse, []GroupResponse](ctx, c, GET, GroupsEndpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var objects []exportedObject
+	for _, group := range *groups {
+		members, err := DoRequest[UserBody, []UserBody](ctx, c, GET, GroupsEndpoint+group.Id+MembersSuffix, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, member := range *members {
+			id, err := FormLocalGroupMemberId([]string{group.Id, member.Id})
+			if err != nil {
+				return nil, err
+			}
+			// Such as the admin user in the Administrators group
+			_, builtInGroup := builtInGroups[group.Id]
+			_, builtInUser := builtInUsers[member.Id]
+			objects = append(objects, exportedObject{Id: id, Label: group.Name + "_" + member.Name, BuiltIn: builtInGroup && builtInUser})
+		}
+	}
+	return objects, nil
+}
+
+func listExportedRoles(ctx context.Context, c *Client) ([]exportedObject, error) {
+	roles, err := DoRequest[Role, map[string]Role](ctx, c, GET, RolesEndpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var objects []exportedObject
+	for name := range *roles {
+		objects = append(objects, exportedObject{Id: name, Label: name, BuiltIn: StringSliceContains(builtInRoles, name)})
+	}
+	sort.Slice(objects, func(i, j int) bool { return objects[i].Id < objects[j].Id })
+
+	return objects, nil
+}
+
+type RoleMembersResponse struct {
+	Members []string `json:"members"`
+}
+
+// Role members are imported as {role_name}:{auth_id}
+func listExportedRoleMembers(ctx context.Context, c *Client) ([]exportedObject, error) {
+	roles, err := listExportedRoles(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+
+	var objects []exportedObject
+	for _, role := range roles {
+		members, err := DoRequest[RoleMembersResponse, RoleMembersResponse](ctx, c, GET, RolesEndpoint+role.Id+MembersEnding, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, authId := range members.Members {
+			member, err := DoRequest[RoleMemberResponse, RoleMemberResponse](ctx, c, GET, RolesEndpoint+role.Id+MembersSuffix+authId, nil)
+			if err != nil {
+				return nil, err
+			}
+
+			id, err := FormRoleMemberId([]string{role.Id, authId})
+			if err != nil {
+				return nil, err
+			}
+			name := member.Name
+			if name == "" {
+				name = authId
+			}
+			// Such as the Administrators group in the Administrators role. Members added to built-in roles
+			// are exported.
+			builtIn := role.BuiltIn && member.Domain == "LOCAL" && isBuiltInLocalName(member.Name)
+			objects = append(objects, exportedObject{Id: id, Label: role.Id + "_" + name, BuiltIn: builtIn})
+		}
+	}
+	return objects, nil
+}
+
+func listExportedSmbShares(ctx context.Context, c *Client) ([]exportedObject, error) {
+	shares, err := DoRequest[SmbShare, []SmbShare](ctx, c, GET, SmbSharesEndpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var objects []exportedObject
+	for _, share := range *shares {
+		builtIn := share.ShareName == builtInSmbShareName && share.FsPath == "/"
+		objects = append(objects, exportedObject{Id: share.Id, Label: share.ShareName, BuiltIn: builtIn})
+	}
+	return objects, nil
+}
+
+func listExportedNfsExports(ctx context.Context, c *Client) ([]exportedObject, error) {
+	exports, err := DoRequest[NfsExport, []NfsExport](ctx, c, GET, NfsExportsEndpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var objects []exportedObject
+	for _, export := range *exports {
+		builtIn := export.ExportPath == builtInNfsExportPath && export.FsPath == "/"
+		objects = append(objects, exportedObject{Id: export.Id, Label: "export_" + export.ExportPath, BuiltIn: builtIn})
+	}
+	return objects, nil
+}
+
+func listExportedInterfaces(ctx context.Context, c *Client) ([]exportedObject, error) {
+	interfaces, err := DoRequest[InterfaceConfigurationResponse, []InterfaceConfigurationResponse](ctx, c, GET, InterfaceConfigurationEndpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var objects []exportedObject
+	for _, i := range *interfaces {
+		objects = append(objects, exportedObject{Id: strconv.Itoa(i.Id), Label: i.Name})
+	}
+	return objects, nil
+}
+
+// Networks are imported as {interface_id}:{network_id}
+func listExportedNetworks(ctx context.Context, c *Client) ([]exportedObject, error) {
+	interfaces, err := listExportedInterfaces(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+
+	var objects []exportedObject
+	for _, i := range interfaces {
+		networks, err := DoRequest[NetworkConfigurationResponse, []NetworkConfigurationResponse](ctx, c, GET,
+			InterfaceConfigurationEndpoint+i.Id+NetworksEndpointSuffix, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, network := range *networks {
+			objects = append(objects, exportedObject{
+				Id:    i.Id + ":" + strconv.Itoa(network.Id),
+				Label: i.Label + "_" + network.Name,
+			})
+		}
+	}
+	return objects, nil
+}
@@ -0,0 +1,202 @@
+package qumulo
+
+import (
+	"bytes"
+	"context"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2/hclparse"
+)
+
+func TestExportCluster(t *testing.T) {
+	f := newFakeCluster()
+	defer f.Close()
+
+	ctx := context.Background()
+	u, _ := url.Parse(f.URL)
+	opts := exportOptions{
+		clusterOptions: clusterOptions{
+			Host:     u.Hostname(),
+			Port:     u.Port(),
+			Username: fakeClusterUsername,
+			Password: fakeClusterPassword,
+		},
+		OutputDir: t.TempDir(),
+	}
+
+	c, err := NewClient(ctx, &opts.Host, &opts.Port, &opts.Username, &opts.Password)
+	if err != nil {
+		t.Fatalf("unexpected error signing in to the fake cluster: %v", err)
+	}
+	create := func(uri string, body interface{}) map[string]interface{} {
+		created, err := DoRequest[interface{}, map[string]interface{}](ctx, c, POST, uri, &body)
+		if err != nil {
+			t.Fatalf("unexpected error creating %s: %v", uri, err)
+		}
+		return *created
+	}
+	user := create(UsersEndpoint, UserBody{Name: "Jane Doe", PrimaryGroup: "513", Password: "hunter2"})
+	create(UsersEndpoint, UserBody{Name: "jane.doe", PrimaryGroup: "513"})
+	group := create(GroupsEndpoint, CreateGroupRequest{Name: "Editors"})
+	create(GroupsEndpoint+group["id"].(string)+MembersSuffix, GroupMemberRequest{MemberId: user["id"].(string), GroupId: group["id"].(string)})
+	create(RolesEndpoint, Role{Name: "Operators", Description: "Read only", Privileges: []string{"PRIVILEGE_AD_READ"}})
+	member := create(RolesEndpoint+"Operators"+MembersEnding, RoleMemberAddRequest{Name: "Jane Doe"})
+	create(SmbSharesEndpoint, SmbShare{ShareName: "Projects", FsPath: "/", Permissions: []SmbPermission{{
+		Type:    "ALLOWED",
+		Trustee: SmbTrustee{Name: "Jane Doe"},
+		Rights:  []string{"READ"},
+	}}})
+
+	var stdout, stderr bytes.Buffer
+	if err := exportCluster(ctx, opts, &stdout, &stderr); err == nil {
+		t.Errorf("expected the networking resources, which the fake cluster doesn't model, to fail the export")
+	}
+	if !strings.Contains(stderr.String(), "skipping qumulo_interface_configuration") {
+		t.Errorf("expected a warning about skipping interfaces, got %q", stderr.String())
+	}
+
+	parser := hclparse.NewParser()
+	files := map[string]string{}
+	for _, name := range []string{ExportResourcesFile, ExportImportsFile} {
+		path := filepath.Join(opts.OutputDir, name)
+		if _, diags := parser.ParseHCLFile(path); diags.HasErrors() {
+			t.Fatalf("%s is not valid HCL: %v", name, diags)
+		}
+		b, _ := os.ReadFile(path)
+		files[name] = string(b)
+	}
+
+	for _, expected := range []string{
+		`resource "qumulo_cluster_name" "cluster" {`,
+		`cluster_name = "fake-cluster"`,
+		`resource "qumulo_local_user" "jane_doe" {`,
+		`resource "qumulo_local_user" "jane_doe_2" {`,
+		`resource "qumulo_local_group_member" "editors_jane_doe" {`,
+		`resource "qumulo_role_member" "operators_jane_doe" {`,
+		`privileges  = ["PRIVILEGE_AD_READ"]`,
+		`resource "qumulo_smb_share" "projects" {`,
+		"  permissions {\n",
+	} {
+		if !strings.Contains(files[ExportResourcesFile], expected) {
+			t.Errorf("expected %s to contain %q:\n%s", ExportResourcesFile, expected, files[ExportResourcesFile])
+		}
+	}
+	for _, unexpected := range []string{"hunter2", "password =", "password_version", "on_destroy", "etag", `resource "qumulo_ad_settings"`} {
+		if strings.Contains(files[ExportResourcesFile], unexpected) {
+			t.Errorf("expected %s not to contain %q:\n%s", ExportResourcesFile, unexpected, files[ExportResourcesFile])
+		}
+	}
+
+	memberId, _ := FormRoleMemberId([]string{"Operators", member["auth_id"].(string)})
+	groupMemberId, _ := FormLocalGroupMemberId([]string{group["id"].(string), user["id"].(string)})
+	for _, expected := range []string{
+		"to = qumulo_cluster_name.cluster\n  id = \"" + fakeClusterUuid + "\"",
+		"to = qumulo_role_member.operators_jane_doe\n  id = \"" + memberId + "\"",
+		"to = qumulo_local_group_member.editors_jane_doe\n  id = \"" + groupMemberId + "\"",
+	} {
+		if !strings.Contains(files[ExportImportsFile], expected) {
+			t.Errorf("expected %s to contain %q:\n%s", ExportImportsFile, expected, files[ExportImportsFile])
+		}
+	}
+}
+
+func TestExportClusterSkipsBuiltIns(t *testing.T) {
+	f := newFakeCluster()
+	defer f.Close()
+	f.addBuiltIns()
+
+	ctx := context.Background()
+	u, _ := url.Parse(f.URL)
+	opts := exportOptions{
+		clusterOptions: clusterOptions{
+			Host:     u.Hostname(),
+			Port:     u.Port(),
+			Username: fakeClusterUsername,
+			Password: fakeClusterPassword,
+		},
+		Resources: []string{"qumulo_local_user", "qumulo_local_group", "qumulo_local_group_member", "qumulo_role",
+			"qumulo_role_member", "qumulo_smb_share", "qumulo_nfs_export"},
+		OutputDir: t.TempDir(),
+	}
+
+	c, err := NewClient(ctx, &opts.Host, &opts.Port, &opts.Username, &opts.Password)
+	if err != nil {
+		t.Fatalf("unexpected error signing in to the fake cluster: %v", err)
+	}
+	create := func(uri string, body interface{}) map[string]interface{} {
+		created, err := DoRequest[interface{}, map[string]interface{}](ctx, c, POST, uri, &body)
+		if err != nil {
+			t.Fatalf("unexpected error creating %s: %v", uri, err)
+		}
+		return *created
+	}
+	user := create(UsersEndpoint, UserBody{Name: "jane", PrimaryGroup: "513"})
+	create(GroupsEndpoint+"512"+MembersSuffix, GroupMemberRequest{MemberId: user["id"].(string), GroupId: "512"})
+	create(RolesEndpoint+"Observers"+MembersEnding, RoleMemberAddRequest{Name: "jane"})
+	create(SmbSharesEndpoint, SmbShare{ShareName: "Projects", FsPath: "/"})
+
+	var stdout, stderr bytes.Buffer
+	if err := exportCluster(ctx, opts, &stdout, &stderr); err != nil {
+		t.Fatalf("unexpected error exporting the cluster: %v\n%s", err, stderr.String())
+	}
+	if !strings.Contains(stderr.String(), "Skipped 14 built-in objects") {
+		t.Errorf("expected a note about the skipped built-in objects, got %q", stderr.String())
+	}
+
+	b, _ := os.ReadFile(filepath.Join(opts.OutputDir, ExportResourcesFile))
+	resources := string(b)
+
+	// Members added to built-in groups and roles are exported, unlike the groups and roles themselves
+	for _, expected := range []string{
+		`resource "qumulo_local_user" "jane" {`,
+		`resource "qumulo_local_group_member" "administrators_jane" {`,
+		`resource "qumulo_role_member" "observers_jane" {`,
+		`resource "qumulo_smb_share" "projects" {`,
+	} {
+		if !strings.Contains(resources, expected) {
+			t.Errorf("expected %s to contain %q:\n%s", ExportResourcesFile, expected, resources)
+		}
+	}
+	for _, unexpected := range []string{
+		`resource "qumulo_local_user" "admin"`,
+		`resource "qumulo_local_user" "guest"`,
+		`resource "qumulo_local_group" "`,
+		`resource "qumulo_local_group_member" "administrators_admin"`,
+		`resource "qumulo_role" "`,
+		`resource "qumulo_role_member" "administrators_administrators"`,
+		`resource "qumulo_smb_share" "files"`,
+		`resource "qumulo_nfs_export" "`,
+	} {
+		if strings.Contains(resources, unexpected) {
+			t.Errorf("expected %s not to contain %q:\n%s", ExportResourcesFile, unexpected, resources)
+		}
+	}
+}
+
+func TestUniqueExportLabel(t *testing.T) {
+	labels := map[string]bool{}
+
+	cases := []struct {
+		name     string
+		expected string
+	}{
+		{"Files", "files"},
+		{"files", "files_2"},
+		{"/home/jane", "home_jane"},
+		{"1:500", "_1_500"},
+		{"", "_"},
+	}
+	for _, tc := range cases {
+		if got := uniqueExportLabel(labels, "qumulo_smb_share", tc.name); got != tc.expected {
+			t.Errorf("expected the label for %q to be %q, got %q", tc.name, tc.expected, got)
+		}
+	}
+
+	if got := uniqueExportLabel(labels, "qumulo_nfs_export", "Files"); got != "files" {
+		t.Errorf("expected labels to be unique per resource type, got %q", got)
+	}
+}
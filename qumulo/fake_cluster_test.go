@@ -0,0 +1,652 @@
+package qumulo
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Acceptance tests run against an in-memory fake of the Qumulo REST API unless this is set, in which
+// case they use the cluster given by QUMULO_HOST, QUMULO_PORT, QUMULO_USERNAME and QUMULO_PASSWORD
+const RealClusterEnvVar = "QUMULO_TEST_REAL_CLUSTER"
+
+const fakeClusterUsername = "admin"
+const fakeClusterPassword = "Admin123"
+const fakeClusterBearerToken = "fake-bearer-token"
+const fakeClusterUuid = "00000000-0000-4000-8000-000000000001"
+
+// The file ID of the root directory, as on a real cluster
+const fakeClusterRootId = "2"
+
+var fakeClusterOnce sync.Once
+var testFakeCluster *fakeCluster
+
+func usingFakeCluster() bool {
+	return os.Getenv(RealClusterEnvVar) == ""
+}
+
+// Starts the fake cluster shared by every acceptance test in the package and points the provider at it
+func startFakeCluster(t *testing.T) {
+	fakeClusterOnce.Do(func() {
+		testFakeCluster = newFakeCluster()
+
+		u, _ := url.Parse(testFakeCluster.URL)
+		os.Setenv("QUMULO_HOST", u.Hostname())
+		os.Setenv("QUMULO_PORT", u.Port())
+		os.Setenv("QUMULO_USERNAME", fakeClusterUsername)
+		os.Setenv("QUMULO_PASSWORD", fakeClusterPassword)
+	})
+}
+
+// Skips tests of API areas the fake cluster doesn't model, such as networking and hardware, unless they
+// are replayed from a cassette recorded against a real cluster
+func testAccPreCheckRealCluster(t *testing.T) {
+	if usingFakeCluster() && !(replayingCassette(t) && cassetteExists(t)) {
+		t.Skipf("%s requires a real cluster; set %s to run it", t.Name(), RealClusterEnvVar)
+	}
+
+	testAccPreCheck(t)
+}
+
+// Skips tests which inspect what the fake cluster was sent, such as secrets a real cluster doesn't
+// return
+func testAccPreCheckFakeCluster(t *testing.T) {
+	if !usingFakeCluster() || replayingCassette(t) {
+		t.Skipf("%s inspects the requests sent to the fake cluster; unset %s and %s to run it", t.Name(),
+			RealClusterEnvVar, CassetteModeEnvVar)
+	}
+
+	testAccPreCheck(t)
+}
+
+type fakeCluster struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	objects  map[string]map[string]interface{}
+	defaults map[string]map[string]interface{}
+	versions map[string]int
+	nextId   int
+}
+
+// Collections whose members are created with POST; members are keyed by the field given here, or by
+// a generated ID if it is empty
+var fakeClusterCollections = map[string]string{
+	UsersEndpoint:          "",
+	GroupsEndpoint:         "",
+	RolesEndpoint:          "name",
+	SmbSharesEndpoint:      "",
+	NfsExportsEndpoint:     "",
+	DirectoryQuotaEndpoint: "id",
+}
+
+// Clusters ignore the schema description given with the RFC2307 schema and use this one instead
+var fakeClusterRfc2307Description = LdapSchemaDescription{
+	GroupMemberAttribute:         "memberUid",
+	UserGroupIdentifierAttribute: "uid",
+	LoginNameAttribute:           "uid",
+	GroupNameAttribute:           "cn",
+	UserObjectClass:              "posixAccount",
+	GroupObjectClass:             "posixGroup",
+	UidNumberAttribute:           "uidNumber",
+	GidNumberAttribute:           "gidNumber",
+}
+
+func newFakeCluster() *fakeCluster {
+	f := &fakeCluster{
+		objects:  map[string]map[string]interface{}{},
+		defaults: map[string]map[string]interface{}{},
+		versions: map[string]int{},
+		nextId:   1000,
+	}
+
+	banner := ""
+	ldapDefaults := LdapServerDefaults
+	ldapDefaults.LdapSchemaDescription = fakeClusterRfc2307Description
+	singletons := map[string]interface{}{
+		ClusterSettingsEndpoint:       ClusterSettingsBody{ClusterName: "fake-cluster"},
+		SmbServerEndpoint:             SmbServerDefaults,
+		LdapServerEndpoint:            ldapDefaults,
+		SyslogConfigEndpoint:          SyslogConfigDefaults,
+		TimeConfigurationEndpoint:     TimeConfigurationDefaults,
+		MonitoringEndpoint:            MonitoringDefaults,
+		CloudWatchConfigEndpoint:      CloudWatchConfigDefaults,
+		FtpServerEndpoint:             FtpServerDefaults,
+		NfsSettingsEndpoint:           NfsSettingsDefaults,
+		WebUiEndpoint:                 WebUiBody{InactivityTimeout: WebUiTimeout{Nanoseconds: "900000000000"}, LoginBanner: &banner},
+		FileSystemPermissionsEndpoint: FileSystemPermissionsSettingsBody{Mode: "NATIVE"},
+		FileSystemAtimeEndpoint:       FileSystemAtimeSettingsBody{Enabled: false, Granularity: "HOUR"},
+		AdSettingsEndpoint:            ActiveDirectorySettingsBody{Signing: WantSigning.String(), Sealing: WantSealing.String(), Crypto: WantCrypto.String()},
+		AdStatusEndpoint:              ActiveDirectoryStatusBody{Status: "NOT_IN_DOMAIN"},
+		AdMonitorEndpoint:             ActiveDirectoryMonitorResponse{Status: "NOT_IN_DOMAIN"},
+		AdPreferredDcsEndpoint:        ActiveDirectoryPreferredDcsBody{Dcs: []string{}},
+		LdapStatusEndpoint:            LdapStatusResponse{LdapConnectionStates: []ActiveDirectoryLdapStates{}},
+		SslEndpoint:                   SslResponse{},
+		SslCaEndpoint:                 SslCaBody{},
+		NodeStateEndpoint:             NodeStateResponse{NodeId: 1, State: "ACTIVE", ClusterId: fakeClusterUuid},
+	}
+	for uri, body := range singletons {
+		f.defaults[uri] = toFakeObject(body)
+		f.objects[uri] = toFakeObject(body)
+	}
+	f.objects[FilesEndpoint+fakeClusterRootId] = toFakeObject(FileAttributesResponse{Id: fakeClusterRootId, Path: "/"})
+
+	f.Server = httptest.NewTLSServer(http.HandlerFunc(f.serveHTTP))
+	return f
+}
+
+// Adds the objects a new cluster comes with: the admin and guest users, their groups, the built-in roles
+// with the Administrators group as a member of the Administrators role, and the share and export of the
+// root directory
+func (f *fakeCluster) addBuiltIns() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	add := func(path string, body interface{}) {
+		f.objects[path] = toFakeObject(body)
+		f.versions[path]++
+	}
+	for id, name := range builtInUsers {
+		add(UsersEndpoint+id, UserBody{Id: id, Name: name, PrimaryGroup: "513", Sid: "S-1-5-21-1000-" + id})
+	}
+	for id, name := range builtInGroups {
+		add(GroupsEndpoint+id, GroupResponse{Id: id, Name: name, Sid: "S-1-5-21-1000-" + id})
+	}
+	add(GroupsEndpoint+"512"+MembersSuffix+"500", GroupMemberRequest{MemberId: "500", GroupId: "512"})
+	add(GroupsEndpoint+"513"+MembersSuffix+"500", GroupMemberRequest{MemberId: "500", GroupId: "513"})
+	add(GroupsEndpoint+"514"+MembersSuffix+"501", GroupMemberRequest{MemberId: "501", GroupId: "514"})
+	for _, name := range builtInRoles {
+		add(RolesEndpoint+name, Role{Name: name, Description: "Built-in " + name + " role", Privileges: []string{}})
+	}
+	add(RolesEndpoint+"Administrators"+MembersSuffix+"512", map[string]interface{}{"domain": "LOCAL", "auth_id": "512",
+		"uid": "", "gid": "", "sid": "S-1-5-21-1000-512", "name": "Administrators"})
+	add(SmbSharesEndpoint+"1", SmbShare{Id: "1", ShareName: builtInSmbShareName, FsPath: "/", Permissions: []SmbPermission{}})
+	add(NfsExportsEndpoint+"1", NfsExport{Id: "1", ExportPath: builtInNfsExportPath, FsPath: "/"})
+}
+
+func toFakeObject(body interface{}) map[string]interface{} {
+	rb, _ := json.Marshal(body)
+
+	var object map[string]interface{}
+	json.Unmarshal(rb, &object)
+	return object
+}
+
+func (f *fakeCluster) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var body map[string]interface{}
+	if rb, _ := io.ReadAll(r.Body); len(rb) > 0 {
+		if err := json.Unmarshal(rb, &body); err != nil {
+			f.writeError(w, http.StatusBadRequest, "malformed request body: %v", err)
+			return
+		}
+	}
+
+	path := r.URL.Path
+	if path == AuthEndpoint && r.Method == http.MethodPost {
+		if body["username"] != fakeClusterUsername || body["password"] != fakeClusterPassword {
+			f.writeError(w, http.StatusUnauthorized, "invalid credentials")
+			return
+		}
+		f.writeObject(w, path, map[string]interface{}{"bearer_token": fakeClusterBearerToken})
+		return
+	}
+
+	if r.Header.Get("Authorization") != "Bearer "+fakeClusterBearerToken {
+		f.writeError(w, http.StatusUnauthorized, "missing or invalid bearer token")
+		return
+	}
+
+	if r.Method == http.MethodPut || r.Method == http.MethodPatch {
+		if etag := r.Header.Get("If-Match"); etag != "" && etag != f.etag(path) {
+			f.writeError(w, http.StatusPreconditionFailed, "%s was modified", path)
+			return
+		}
+	}
+
+	if body == nil {
+		body = map[string]interface{}{}
+	}
+
+	if strings.HasPrefix(path, DirectoryQuotaStatusEndpoint) && r.Method == http.MethodGet {
+		f.serveQuotaStatus(w, strings.TrimPrefix(path, DirectoryQuotaStatusEndpoint))
+		return
+	}
+
+	if path == TimeStatusEndpoint && r.Method == http.MethodGet {
+		f.writeObject(w, path, TimeStatusResponse{
+			Time:      time.Now().UTC().Format(time.RFC3339Nano),
+			Timezone:  "UTC",
+			NtpServer: "0.qumulo.pool.ntp.org",
+			Synced:    true,
+		})
+		return
+	}
+
+	if f.serveActiveDirectory(w, r.Method, path, body) {
+		return
+	}
+
+	// Files are referred to by ID or by escaped path, so the path is matched before it is unescaped
+	if f.serveDirectories(w, r.Method, r.URL.EscapedPath(), body) {
+		return
+	}
+
+	if collection, id, ok := f.collection(path); ok {
+		f.serveCollection(w, r.Method, collection, id, body)
+		return
+	}
+
+	if _, ok := f.defaults[path]; ok {
+		f.serveObject(w, r.Method, path, body)
+		return
+	}
+
+	f.writeError(w, http.StatusNotFound, "%s %s is not implemented by the fake cluster", r.Method, path)
+}
+
+// Splits a path into its collection and member ID. Nested collections such as group and role members
+// are collections of their own.
+func (f *fakeCluster) collection(path string) (string, string, bool) {
+	for collection := range fakeClusterCollections {
+		if !strings.HasPrefix(path, collection) {
+			continue
+		}
+
+		rest := strings.TrimPrefix(path, collection)
+		if strings.HasSuffix(rest, "/members") {
+			rest += "/"
+		}
+		if i := strings.Index(rest, "/members/"); i >= 0 {
+			return collection + rest[:i] + "/members/", rest[i+len("/members/"):], true
+		}
+		return collection, rest, true
+	}
+
+	return "", "", false
+}
+
+func (f *fakeCluster) serveCollection(w http.ResponseWriter, method, collection, id string, body map[string]interface{}) {
+	switch {
+	case method == http.MethodPost && id == "":
+		key := fakeClusterCollections[collection]
+		if strings.HasSuffix(collection, "/members/") {
+			key = "member_id"
+			if strings.HasPrefix(collection, RolesEndpoint) {
+				key = "auth_id"
+			}
+		}
+
+		if key == "" {
+			key = "id"
+		}
+
+		if v, ok := body[key].(string); ok && v != "" {
+			id = v
+		} else {
+			f.nextId++
+			id = strconv.Itoa(f.nextId)
+			body[key] = id
+		}
+
+		if collection == UsersEndpoint || collection == GroupsEndpoint {
+			body["sid"] = fmt.Sprintf("S-1-5-21-1000-%s", id)
+		}
+		if collection == UsersEndpoint {
+			delete(body, "password")
+			body["can_change_password"] = true
+		}
+		if strings.HasPrefix(collection, RolesEndpoint) && key == "auth_id" {
+			f.resolveRoleMember(body)
+		}
+
+		if _, exists := f.objects[collection+id]; exists {
+			f.writeError(w, http.StatusConflict, "%s%s already exists", collection, id)
+			return
+		}
+		f.objects[collection+id] = body
+		f.versions[collection+id]++
+		f.writeObject(w, collection+id, body)
+
+	case method == http.MethodGet && id == "":
+		f.serveListing(w, collection)
+
+	case id == "":
+		f.writeError(w, http.StatusMethodNotAllowed, "%s %s is not allowed", method, collection)
+
+	default:
+		f.serveObject(w, method, collection+id, body)
+	}
+}
+
+// Collections are listed as arrays, except roles, which are listed as an object keyed by role name
+func (f *fakeCluster) serveListing(w http.ResponseWriter, collection string) {
+	var paths []string
+	for path := range f.objects {
+		if strings.HasPrefix(path, collection) && !strings.Contains(strings.TrimPrefix(path, collection), "/") {
+			paths = append(paths, path)
+		}
+	}
+	sort.Strings(paths)
+
+	// Group members are listed as the users themselves, and role members by their auth IDs
+	if strings.HasPrefix(collection, GroupsEndpoint) && strings.HasSuffix(collection, MembersSuffix) {
+		users := []interface{}{}
+		for _, path := range paths {
+			if user, ok := f.objects[UsersEndpoint+f.objects[path]["member_id"].(string)]; ok {
+				users = append(users, user)
+			}
+		}
+		f.writeObject(w, collection, users)
+		return
+	}
+	if strings.HasPrefix(collection, RolesEndpoint) && strings.HasSuffix(collection, MembersSuffix) {
+		authIds := []interface{}{}
+		for _, path := range paths {
+			authIds = append(authIds, f.objects[path]["auth_id"])
+		}
+		f.writeObject(w, collection, map[string]interface{}{"members": authIds})
+		return
+	}
+
+	if collection == RolesEndpoint {
+		roles := map[string]interface{}{}
+		for _, path := range paths {
+			roles[strings.TrimPrefix(path, collection)] = f.objects[path]
+		}
+		f.writeObject(w, collection, roles)
+		return
+	}
+
+	members := []interface{}{}
+	for _, path := range paths {
+		members = append(members, f.objects[path])
+	}
+	f.writeObject(w, collection, members)
+}
+
+func (f *fakeCluster) serveObject(w http.ResponseWriter, method, path string, body map[string]interface{}) {
+	object, ok := f.objects[path]
+	if !ok {
+		f.writeError(w, http.StatusNotFound, "%s not found", path)
+		return
+	}
+
+	switch method {
+	case http.MethodGet:
+	case http.MethodPut:
+		if id, ok := object["id"]; ok {
+			body["id"] = id
+		}
+		if strings.HasPrefix(path, UsersEndpoint) {
+			delete(body, "password")
+			body["sid"], body["can_change_password"] = object["sid"], object["can_change_password"]
+		}
+		object = body
+	case http.MethodPatch:
+		object = f.mergePatch(object, body, f.defaults[path])
+	case http.MethodDelete:
+		if _, singleton := f.defaults[path]; singleton {
+			object = toFakeObject(f.defaults[path])
+			break
+		}
+		delete(f.objects, path)
+		f.versions[path]++
+		w.WriteHeader(http.StatusOK)
+		return
+	default:
+		f.writeError(w, http.StatusMethodNotAllowed, "%s %s is not allowed", method, path)
+		return
+	}
+
+	if path == LdapServerEndpoint && object["ldap_schema"] == "RFC2307" {
+		object["ldap_schema_description"] = toFakeObject(fakeClusterRfc2307Description)
+	}
+
+	if method != http.MethodGet {
+		f.objects[path] = object
+		f.versions[path]++
+	}
+	f.writeObject(w, path, object)
+}
+
+// Applies a JSON merge patch, resetting fields patched to null to their defaults
+func (f *fakeCluster) mergePatch(object, patch, defaults map[string]interface{}) map[string]interface{} {
+	merged := map[string]interface{}{}
+	for k, v := range object {
+		merged[k] = v
+	}
+
+	for k, v := range patch {
+		switch v := v.(type) {
+		case nil:
+			if d, ok := defaults[k]; ok {
+				merged[k] = d
+			} else {
+				delete(merged, k)
+			}
+		case map[string]interface{}:
+			current, _ := merged[k].(map[string]interface{})
+			nestedDefaults, _ := defaults[k].(map[string]interface{})
+			merged[k] = f.mergePatch(current, v, nestedDefaults)
+		default:
+			merged[k] = v
+		}
+	}
+
+	return merged
+}
+
+// Role members are identified by any of their IDs or their name; the cluster returns all of them
+func (f *fakeCluster) resolveRoleMember(member map[string]interface{}) {
+	for path, user := range f.objects {
+		if strings.HasPrefix(path, UsersEndpoint) && user["name"] == member["name"] && member["name"] != "" {
+			member["sid"], member["uid"] = user["sid"], user["uid"]
+		}
+	}
+
+	if member["domain"] == nil || member["domain"] == "" {
+		member["domain"] = "LOCAL"
+	}
+	for _, k := range []string{"uid", "gid", "sid", "name"} {
+		if member[k] == nil {
+			member[k] = ""
+		}
+	}
+}
+
+// Quotas are enforced on an empty file system, so no capacity is ever used. Listings fit in one page.
+func (f *fakeCluster) serveQuotaStatus(w http.ResponseWriter, id string) {
+	if id == "" {
+		quotas := []DirectoryQuotaStatusResponse{}
+		for path, quota := range f.objects {
+			if strings.HasPrefix(path, DirectoryQuotaEndpoint) {
+				id := strings.TrimPrefix(path, DirectoryQuotaEndpoint)
+				quotas = append(quotas, DirectoryQuotaStatusResponse{
+					Id:            id,
+					Path:          f.directoryPath(id),
+					Limit:         quota["limit"].(string),
+					CapacityUsage: "0",
+				})
+			}
+		}
+
+		f.writeObject(w, DirectoryQuotaStatusEndpoint, DirectoryQuotaStatusListResponse{Quotas: quotas})
+		return
+	}
+
+	quota, ok := f.objects[DirectoryQuotaEndpoint+id]
+	if !ok {
+		f.writeError(w, http.StatusNotFound, "quota %s not found", id)
+		return
+	}
+
+	f.writeObject(w, DirectoryQuotaStatusEndpoint+id, DirectoryQuotaStatusResponse{
+		Id:            id,
+		Path:          f.directoryPath(id),
+		Limit:         quota["limit"].(string),
+		CapacityUsage: "0",
+	})
+}
+
+// Joins and leaves complete immediately, so the monitor never reports an operation in progress
+func (f *fakeCluster) serveActiveDirectory(w http.ResponseWriter, method, path string, body map[string]interface{}) bool {
+	if method != http.MethodPost {
+		return false
+	}
+
+	status := f.objects[AdStatusEndpoint]
+	switch path {
+	case AdJoinEndpoint:
+		status = map[string]interface{}{"status": "JOINED_TO_DOMAIN", "dcs": []interface{}{}, "ldap_connection_states": []interface{}{}}
+		for _, k := range []string{"domain", "domain_netbios", "ou", "use_ad_posix_attributes", "base_dn"} {
+			status[k] = body[k]
+		}
+		if status["domain_netbios"] == "" {
+			status["domain_netbios"] = strings.ToUpper(strings.Split(body["domain"].(string), ".")[0])
+		}
+		if status["base_dn"] == "" {
+			status["base_dn"] = defaultAdBaseDn(body["domain"].(string))
+		}
+	case AdReconfigureEndpoint:
+		for _, k := range []string{"ou", "use_ad_posix_attributes", "base_dn"} {
+			if v, ok := body[k]; ok {
+				status[k] = v
+			}
+		}
+		if status["base_dn"] == "" {
+			status["base_dn"] = defaultAdBaseDn(status["domain"].(string))
+		}
+	case AdLeaveEndpoint:
+		status = toFakeObject(f.defaults[AdStatusEndpoint])
+		// Kept so that tests can check which credentials the cluster left with
+		f.objects[AdLeaveEndpoint] = body
+	default:
+		return false
+	}
+
+	f.objects[AdStatusEndpoint] = status
+	f.objects[AdMonitorEndpoint] = map[string]interface{}{"status": status["status"], "domain": status["domain"]}
+	f.writeObject(w, path, map[string]interface{}{"monitor_uri": AdMonitorEndpoint})
+	return true
+}
+
+// Like a real cluster, the fake searches the Users container when no base DN is given
+// Quotas may be set on directories the fake cluster doesn't model, which have no path
+func (f *fakeCluster) directoryPath(id string) string {
+	path, _ := f.objects[FilesEndpoint+id]["path"].(string)
+	return path
+}
+
+// Models the directories, which are all that tests create in the file system. Directory paths end
+// with a slash, as on a real cluster.
+func (f *fakeCluster) serveDirectories(w http.ResponseWriter, method, escapedPath string, body map[string]interface{}) bool {
+	rest := strings.TrimPrefix(escapedPath, FilesEndpoint)
+	ref, action, _ := strings.Cut(rest, "/")
+	if rest == escapedPath || ref == "" || ref == "quotas" {
+		return false
+	}
+
+	dir, ok := f.directory(ref)
+	if !ok {
+		f.writeError(w, http.StatusNotFound, "fs_no_such_entry_error: %s", ref)
+		return true
+	}
+
+	switch {
+	case method == http.MethodGet && action == "info/attributes":
+		f.writeObject(w, escapedPath, dir)
+	case method == http.MethodPost && action == "entries/":
+		if body["action"] != "CREATE_DIRECTORY" {
+			f.writeError(w, http.StatusBadRequest, "only directories can be created, got %v", body["action"])
+			return true
+		}
+		name, _ := body["name"].(string)
+		if name == "" || strings.Contains(name, "/") {
+			f.writeError(w, http.StatusBadRequest, "invalid name %q", name)
+			return true
+		}
+		path := dir["path"].(string) + name + "/"
+		if _, ok := f.directory(url.PathEscape(path)); ok {
+			f.writeError(w, http.StatusConflict, "fs_entry_exists_error: %s", path)
+			return true
+		}
+
+		f.nextId++
+		id := strconv.Itoa(f.nextId)
+		f.objects[FilesEndpoint+id] = toFakeObject(FileAttributesResponse{Id: id, Path: path})
+		f.writeObject(w, escapedPath, f.objects[FilesEndpoint+id])
+	case method == http.MethodDelete && action == "":
+		if dir["id"] == fakeClusterRootId {
+			f.writeError(w, http.StatusBadRequest, "the root directory can't be deleted")
+			return true
+		}
+		delete(f.objects, FilesEndpoint+dir["id"].(string))
+		w.WriteHeader(http.StatusOK)
+	default:
+		f.writeError(w, http.StatusNotFound, "%s %s is not implemented by the fake cluster", method, escapedPath)
+	}
+	return true
+}
+
+// Finds a directory by its ID or escaped path
+func (f *fakeCluster) directory(ref string) (map[string]interface{}, bool) {
+	if dir, ok := f.objects[FilesEndpoint+ref]; ok {
+		return dir, true
+	}
+
+	path, err := url.PathUnescape(ref)
+	if err != nil || !strings.HasPrefix(path, "/") {
+		return nil, false
+	}
+	path = strings.TrimSuffix(path, "/") + "/"
+	for k, dir := range f.objects {
+		if strings.HasPrefix(k, FilesEndpoint) && dir["path"] == path {
+			return dir, true
+		}
+	}
+	return nil, false
+}
+
+func defaultAdBaseDn(domain string) string {
+	return "CN=Users,DC=" + strings.Join(strings.Split(domain, "."), ",DC=")
+}
+
+// Returns the object last written to path and how many times it was written
+func (f *fakeCluster) object(path string) (map[string]interface{}, int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.objects[path], f.versions[path]
+}
+
+func (f *fakeCluster) etag(path string) string {
+	return fmt.Sprintf(`"%d"`, f.versions[path])
+}
+
+func (f *fakeCluster) writeObject(w http.ResponseWriter, path string, object interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", f.etag(path))
+	json.NewEncoder(w).Encode(object)
+}
+
+func (f *fakeCluster) writeError(w http.ResponseWriter, status int, format string, args ...interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"error_class": "fake_cluster_error",
+		"description": fmt.Sprintf(format, args...),
+	})
+}
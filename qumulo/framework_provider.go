@@ -0,0 +1,132 @@
+package qumulo
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-mux/tf5to6server"
+	"github.com/hashicorp/terraform-plugin-mux/tf6muxserver"
+	sdkschema "github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Resources are moving from terraform-plugin-sdk to the plugin framework one at a time. The ones which
+// have moved are listed here and removed from the ResourcesMap of Provider(), which keeps serving the
+// rest, and both are served to Terraform together by ProviderServer.
+var frameworkResources = map[string]func() resource.Resource{
+	"qumulo_role_member": newRoleMemberResource,
+}
+
+// Serves the resources of both providers with protocol version 6. The SDK provider is upgraded from
+// protocol version 5, which requires Terraform 1.0 or later.
+func ProviderServer(ctx context.Context) (func() tfprotov6.ProviderServer, error) {
+	return newProviderServer(ctx, Provider(), NewFrameworkProvider())
+}
+
+func newProviderServer(ctx context.Context, sdkProvider *sdkschema.Provider, frameworkProvider provider.Provider) (func() tfprotov6.ProviderServer, error) {
+	upgradedSdkServer, err := tf5to6server.UpgradeServer(ctx, sdkProvider.GRPCProvider)
+	if err != nil {
+		return nil, err
+	}
+
+	muxServer, err := tf6muxserver.NewMuxServer(ctx,
+		func() tfprotov6.ProviderServer { return upgradedSdkServer },
+		providerserver.NewProtocol6(frameworkProvider),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return muxServer.ProviderServer, nil
+}
+
+type FrameworkProvider struct {
+	// Called when the provider is configured, so that tests can replay cassettes
+	newTransport func() http.RoundTripper
+}
+
+type frameworkProviderModel struct {
+	Host     types.String `tfsdk:"host"`
+	Port     types.String `tfsdk:"port"`
+	Username types.String `tfsdk:"username"`
+	Password types.String `tfsdk:"password"`
+	ReadOnly types.Bool   `tfsdk:"read_only"`
+}
+
+func NewFrameworkProvider() provider.Provider {
+	return &FrameworkProvider{newTransport: newDefaultTransport}
+}
+
+func (p *FrameworkProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
+	resp.TypeName = "qumulo"
+}
+
+// The schema has to be the same as the one of Provider(), since both are given the same configuration
+func (p *FrameworkProvider) Schema(ctx context.Context, req provider.SchemaRequest, resp *provider.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"host": schema.StringAttribute{
+				Optional: true,
+			},
+			"port": schema.StringAttribute{
+				Optional: true,
+			},
+			"username": schema.StringAttribute{
+				Optional: true,
+			},
+			"password": schema.StringAttribute{
+				Optional:  true,
+				Sensitive: true,
+			},
+			"read_only": schema.BoolAttribute{
+				Optional: true,
+			},
+		},
+	}
+}
+
+func (p *FrameworkProvider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
+	var config frameworkProviderModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	host := providerSetting(config.Host.ValueString(), "QUMULO_HOST")
+	port := providerSetting(config.Port.ValueString(), "QUMULO_PORT")
+	username := providerSetting(config.Username.ValueString(), "QUMULO_USERNAME")
+	password := providerSetting(config.Password.ValueString(), "QUMULO_PASSWORD")
+	readOnly, err := providerReadOnly(config.ReadOnly.ValueBool())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid read only setting", err.Error())
+		return
+	}
+
+	c, err := NewClientWithTransport(ctx, &host, &port, &username, &password, p.newTransport())
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to sign in to the cluster", err.Error())
+		return
+	}
+	c.ReadOnly = readOnly
+
+	resp.ResourceData = c
+	resp.DataSourceData = c
+}
+
+func (p *FrameworkProvider) Resources(ctx context.Context) []func() resource.Resource {
+	var resources []func() resource.Resource
+	for _, r := range frameworkResources {
+		resources = append(resources, r)
+	}
+	return resources
+}
+
+func (p *FrameworkProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
+	return nil
+}
@@ -0,0 +1,198 @@
+package qumulo
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// A state written by the terraform-plugin-sdk version of a resource which has moved to the plugin
+// framework, with the configuration it was written for
+type sdkStateFixture struct {
+	Config        json.RawMessage `json:"config"`
+	SchemaVersion int64           `json:"schema_version"`
+	State         json.RawMessage `json:"state"`
+	Private       json.RawMessage `json:"private"`
+}
+
+// The objects the states in testdata/sdk_states refer to, created on an empty fake cluster
+var sdkStateObjects = map[string]func(t *testing.T, ctx context.Context, c *Client){
+	"qumulo_role_member": func(t *testing.T, ctx context.Context, c *Client) {
+		createFakeObject(t, ctx, c, UsersEndpoint, UserBody{Name: "jane", PrimaryGroup: "513"})
+		createFakeObject(t, ctx, c, RolesEndpoint, Role{Name: "Observers", Description: "Read only", Privileges: []string{"PRIVILEGE_AD_READ"}})
+		createFakeObject(t, ctx, c, RolesEndpoint+"Observers"+MembersEnding, RoleMemberAddRequest{Name: "jane"})
+	},
+}
+
+func createFakeObject(t *testing.T, ctx context.Context, c *Client, uri string, body interface{}) {
+	if _, err := DoRequest[interface{}, map[string]interface{}](ctx, c, POST, uri, &body); err != nil {
+		t.Fatalf("unexpected error creating %s: %v", uri, err)
+	}
+}
+
+// Every resource which has moved to the plugin framework has to read the states of its SDK version,
+// refresh them without changes and plan no changes to them
+func TestFrameworkResourcesReadSdkStates(t *testing.T) {
+	for resourceType := range frameworkResources {
+		t.Run(resourceType, func(t *testing.T) {
+			b, err := os.ReadFile(filepath.Join("testdata", "sdk_states", resourceType+".json"))
+			if err != nil {
+				t.Fatalf("expected a state written by the SDK version of the resource: %v", err)
+			}
+			var fixture sdkStateFixture
+			if err := json.Unmarshal(b, &fixture); err != nil {
+				t.Fatalf("unexpected error parsing the fixture: %v", err)
+			}
+			createObjects, ok := sdkStateObjects[resourceType]
+			if !ok {
+				t.Fatalf("expected the objects the fixture refers to in sdkStateObjects")
+			}
+
+			testSdkStateFixture(t, resourceType, fixture, createObjects)
+		})
+	}
+}
+
+func testSdkStateFixture(t *testing.T, resourceType string, fixture sdkStateFixture, createObjects func(t *testing.T, ctx context.Context, c *Client)) {
+	f := newFakeCluster()
+	defer f.Close()
+
+	ctx := context.Background()
+	u, _ := url.Parse(f.URL)
+	host, port, username, password := u.Hostname(), u.Port(), fakeClusterUsername, fakeClusterPassword
+	c, err := NewClientWithTransport(ctx, &host, &port, &username, &password, newDefaultTransport())
+	if err != nil {
+		t.Fatalf("unexpected error signing in to the fake cluster: %v", err)
+	}
+	createObjects(t, ctx, c)
+
+	providerServer, err := ProviderServer(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error creating the provider server: %v", err)
+	}
+	server := providerServer()
+
+	schemas, err := server.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatalf("unexpected error getting the schemas: %v", err)
+	}
+	checkDiagnostics(t, "getting the schemas", schemas.Diagnostics)
+	objectType := schemas.ResourceSchemas[resourceType].ValueType()
+
+	providerConfig, err := tfprotov6.NewDynamicValue(schemas.Provider.ValueType(), tftypes.NewValue(schemas.Provider.ValueType(), map[string]tftypes.Value{
+		"host":      tftypes.NewValue(tftypes.String, host),
+		"port":      tftypes.NewValue(tftypes.String, port),
+		"username":  tftypes.NewValue(tftypes.String, username),
+		"password":  tftypes.NewValue(tftypes.String, password),
+		"read_only": tftypes.NewValue(tftypes.Bool, nil),
+	}))
+	if err != nil {
+		t.Fatalf("unexpected error encoding the provider configuration: %v", err)
+	}
+	configured, err := server.ConfigureProvider(ctx, &tfprotov6.ConfigureProviderRequest{Config: &providerConfig})
+	if err != nil {
+		t.Fatalf("unexpected error configuring the provider: %v", err)
+	}
+	checkDiagnostics(t, "configuring the provider", configured.Diagnostics)
+
+	upgraded, err := server.UpgradeResourceState(ctx, &tfprotov6.UpgradeResourceStateRequest{
+		TypeName: resourceType,
+		Version:  fixture.SchemaVersion,
+		RawState: &tfprotov6.RawState{JSON: fixture.State},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error upgrading the state: %v", err)
+	}
+	checkDiagnostics(t, "upgrading the state", upgraded.Diagnostics)
+
+	read, err := server.ReadResource(ctx, &tfprotov6.ReadResourceRequest{
+		TypeName:     resourceType,
+		CurrentState: upgraded.UpgradedState,
+		Private:      fixture.Private,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error reading the resource: %v", err)
+	}
+	checkDiagnostics(t, "reading the resource", read.Diagnostics)
+
+	sdkState, err := tftypes.ValueFromJSON(fixture.State, objectType)
+	if err != nil {
+		t.Fatalf("unexpected error decoding the fixture state: %v", err)
+	}
+	refreshed, err := read.NewState.Unmarshal(objectType)
+	if err != nil {
+		t.Fatalf("unexpected error decoding the refreshed state: %v", err)
+	}
+	if !refreshed.Equal(sdkState) {
+		t.Errorf("expected refreshing to keep the state of the SDK version:\n%s\ngot\n%s", sdkState, refreshed)
+	}
+
+	// Terraform proposes the configured values, and the prior values of the attributes which aren't
+	// configured
+	config, err := tftypes.ValueFromJSON(fixture.Config, objectType)
+	if err != nil {
+		t.Fatalf("unexpected error decoding the fixture configuration: %v", err)
+	}
+	proposed, err := proposedNewState(refreshed, config)
+	if err != nil {
+		t.Fatalf("unexpected error proposing a new state: %v", err)
+	}
+
+	configValue, _ := tfprotov6.NewDynamicValue(objectType, config)
+	proposedValue, _ := tfprotov6.NewDynamicValue(objectType, proposed)
+	plan, err := server.PlanResourceChange(ctx, &tfprotov6.PlanResourceChangeRequest{
+		TypeName:         resourceType,
+		PriorState:       read.NewState,
+		ProposedNewState: &proposedValue,
+		Config:           &configValue,
+		PriorPrivate:     read.Private,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error planning: %v", err)
+	}
+	checkDiagnostics(t, "planning", plan.Diagnostics)
+
+	if len(plan.RequiresReplace) > 0 {
+		t.Errorf("expected no attributes to require replacing the resource, got %v", plan.RequiresReplace)
+	}
+	planned, err := plan.PlannedState.Unmarshal(objectType)
+	if err != nil {
+		t.Fatalf("unexpected error decoding the planned state: %v", err)
+	}
+	if !planned.Equal(refreshed) {
+		t.Errorf("expected no changes to be planned:\n%s\ngot\n%s", refreshed, planned)
+	}
+}
+
+func checkDiagnostics(t *testing.T, step string, diags []*tfprotov6.Diagnostic) {
+	for _, diagnostic := range diags {
+		if diagnostic.Severity == tfprotov6.DiagnosticSeverityError {
+			t.Fatalf("unexpected error %s: %s: %s", step, diagnostic.Summary, diagnostic.Detail)
+		}
+	}
+}
+
+func proposedNewState(prior, config tftypes.Value) (tftypes.Value, error) {
+	var priorAttributes, configAttributes map[string]tftypes.Value
+	if err := prior.As(&priorAttributes); err != nil {
+		return tftypes.Value{}, err
+	}
+	if err := config.As(&configAttributes); err != nil {
+		return tftypes.Value{}, err
+	}
+
+	proposed := map[string]tftypes.Value{}
+	for k, v := range priorAttributes {
+		proposed[k] = v
+		if !configAttributes[k].IsNull() {
+			proposed[k] = configAttributes[k]
+		}
+	}
+	return tftypes.NewValue(prior.Type(), proposed), nil
+}
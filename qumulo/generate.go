@@ -0,0 +1,5 @@
+package qumulo
+
+// Generate the API structs, schemas and expand and flatten helpers of the resources described in the
+// API schema snapshot:
+//go:generate go run ../tools/resourcegen -spec ../tools/resourcegen/qumulo_api.json -out .
@@ -0,0 +1,190 @@
+// Code generated by tools/resourcegen from qumulo_api.json. DO NOT EDIT.
+
+package qumulo
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var NfsExportsFieldToPresentAs32BitValues = []string{"FILE_IDS", "FILE_SIZES", "FS_SIZE", "ALL"}
+
+var NfsExportsUserMappingsValues = []string{"NFS_MAP_NONE", "NFS_MAP_ALL", "NFS_MAP_ROOT"}
+
+// An NFS export of a directory
+type NfsExport struct {
+	Id string `json:"id"`
+	// The NFS export path
+	ExportPath string `json:"export_path"`
+	// The file system path of the exported directory
+	FsPath                 string           `json:"fs_path"`
+	Description            string           `json:"description"`
+	Restrictions           []NfsRestriction `json:"restrictions"`
+	FieldsToPresentAs32Bit []string         `json:"fields_to_present_as_32_bit"`
+}
+
+func nfsExportSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"id": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"export_path": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		"fs_path": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		"description": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		"restrictions": {
+			Type:     schema.TypeList,
+			Required: true,
+			MinItems: 1,
+			Elem: &schema.Resource{
+				Schema: nfsRestrictionSchema(),
+			},
+		},
+		"fields_to_present_as_32_bit": {
+			Type:     schema.TypeList,
+			Optional: true,
+			Elem: &schema.Schema{
+				Type:             schema.TypeString,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(NfsExportsFieldToPresentAs32BitValues, false)),
+			},
+		},
+	}
+}
+
+func expandNfsExport(d *schema.ResourceData) NfsExport {
+	nfsExport := NfsExport{}
+	nfsExport.Id = d.Get("id").(string)
+	nfsExport.ExportPath = d.Get("export_path").(string)
+	nfsExport.FsPath = d.Get("fs_path").(string)
+	nfsExport.Description = d.Get("description").(string)
+	nfsExport.Restrictions = expandNfsRestrictionList(d.Get("restrictions").([]interface{}))
+	nfsExport.FieldsToPresentAs32Bit = InterfaceSliceToStringSlice(d.Get("fields_to_present_as_32_bit").([]interface{}))
+	return nfsExport
+}
+
+func flattenNfsExport(d *schema.ResourceData, nfsExport *NfsExport) diag.Diagnostics {
+	var errs ErrorCollection
+	errs.addMaybeError(d.Set("id", nfsExport.Id))
+	errs.addMaybeError(d.Set("export_path", nfsExport.ExportPath))
+	errs.addMaybeError(d.Set("fs_path", nfsExport.FsPath))
+	errs.addMaybeError(d.Set("description", nfsExport.Description))
+	errs.addMaybeError(d.Set("restrictions", flattenNfsRestrictionList(nfsExport.Restrictions)))
+	errs.addMaybeError(d.Set("fields_to_present_as_32_bit", nfsExport.FieldsToPresentAs32Bit))
+	return errs.diags
+}
+
+// The hosts an NFS export is available to, and how their users are mapped
+type NfsRestriction struct {
+	HostRestrictions      []string               `json:"host_restrictions"`
+	ReadOnly              bool                   `json:"read_only"`
+	RequirePrivilegedPort bool                   `json:"require_privileged_port"`
+	UserMapping           string                 `json:"user_mapping"`
+	MapToUser             map[string]interface{} `json:"map_to_user,omitempty"`
+	MapToGroup            map[string]interface{} `json:"map_to_group,omitempty"`
+}
+
+func nfsRestrictionSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"host_restrictions": {
+			Type:     schema.TypeList,
+			Required: true,
+			Elem: &schema.Schema{
+				Type:             schema.TypeString,
+				ValidateDiagFunc: validation.ToDiagFunc(validateHostRestriction),
+			},
+		},
+		"read_only": {
+			Type:     schema.TypeBool,
+			Required: true,
+		},
+		"require_privileged_port": {
+			Type:     schema.TypeBool,
+			Required: true,
+		},
+		"user_mapping": {
+			Type:             schema.TypeString,
+			Required:         true,
+			ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(NfsExportsUserMappingsValues, false)),
+		},
+		"map_to_user": {
+			Type:     schema.TypeMap,
+			Optional: true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"map_to_group": {
+			Type:     schema.TypeMap,
+			Optional: true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+	}
+}
+
+func expandNfsRestrictionList(tfList []interface{}) []NfsRestriction {
+	var nfsRestrictionList []NfsRestriction
+	for _, tfElement := range tfList {
+		if tfMap, ok := tfElement.(map[string]interface{}); ok {
+			nfsRestrictionList = append(nfsRestrictionList, expandNfsRestriction(tfMap))
+		} else {
+			nfsRestrictionList = append(nfsRestrictionList, NfsRestriction{})
+		}
+	}
+	return nfsRestrictionList
+}
+
+func expandNfsRestriction(tfMap map[string]interface{}) NfsRestriction {
+	nfsRestriction := NfsRestriction{}
+	if v, ok := tfMap["host_restrictions"].([]interface{}); ok {
+		nfsRestriction.HostRestrictions = InterfaceSliceToStringSlice(v)
+	}
+	if v, ok := tfMap["read_only"].(bool); ok {
+		nfsRestriction.ReadOnly = v
+	}
+	if v, ok := tfMap["require_privileged_port"].(bool); ok {
+		nfsRestriction.RequirePrivilegedPort = v
+	}
+	if v, ok := tfMap["user_mapping"].(string); ok {
+		nfsRestriction.UserMapping = v
+	}
+	if v, ok := tfMap["map_to_user"].(map[string]interface{}); ok {
+		nfsRestriction.MapToUser = v
+	}
+	if v, ok := tfMap["map_to_group"].(map[string]interface{}); ok {
+		nfsRestriction.MapToGroup = v
+	}
+	return nfsRestriction
+}
+
+func flattenNfsRestrictionList(nfsRestrictionList []NfsRestriction) []interface{} {
+	var tfList []interface{}
+	for _, nfsRestriction := range nfsRestrictionList {
+		tfList = append(tfList, flattenNfsRestriction(nfsRestriction))
+	}
+	return tfList
+}
+
+func flattenNfsRestriction(nfsRestriction NfsRestriction) map[string]interface{} {
+	tfMap := map[string]interface{}{}
+	if len(nfsRestriction.HostRestrictions) != 0 {
+		tfMap["host_restrictions"] = nfsRestriction.HostRestrictions
+	}
+	tfMap["read_only"] = nfsRestriction.ReadOnly
+	tfMap["require_privileged_port"] = nfsRestriction.RequirePrivilegedPort
+	tfMap["user_mapping"] = nfsRestriction.UserMapping
+	tfMap["map_to_user"] = nfsRestriction.MapToUser
+	tfMap["map_to_group"] = nfsRestriction.MapToGroup
+	return tfMap
+}
@@ -0,0 +1,148 @@
+package qumulo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// Singleton settings resources can't really be deleted, so on_destroy controls what is left on the
+// cluster once the resource is removed from Terraform
+type OnDestroy int
+
+const (
+	Retain OnDestroy = iota + 1
+	ResetToDefaults
+	RestorePrevious
+)
+
+var OnDestroyValues = []string{"retain", "reset_to_defaults", "restore_previous"}
+
+func (e OnDestroy) String() string {
+	return OnDestroyValues[e-1]
+}
+
+// Settings resources support every on_destroy value unless they are given the ones they support, such
+// as when there are no defaults to reset to
+func onDestroySchema(defaultValue OnDestroy, supported ...OnDestroy) *schema.Schema {
+	values := OnDestroyValues
+	if len(supported) > 0 {
+		values = nil
+		for _, v := range supported {
+			values = append(values, v.String())
+		}
+	}
+
+	return &schema.Schema{
+		Type:             schema.TypeString,
+		Optional:         true,
+		Default:          defaultValue.String(),
+		ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(values, false)),
+	}
+}
+
+// SDKv2 doesn't expose private state to CRUD functions, so the settings found on the cluster before
+// the resource was created are kept in a computed attribute instead. It is sensitive, which hides it
+// from plan output, but it is written to the state and shown by `terraform show -json`, so the settings
+// are only recorded when on_destroy is "restore_previous".
+func previousSettingsSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:      schema.TypeString,
+		Computed:  true,
+		Sensitive: true,
+	}
+}
+
+func recordPreviousSettings[B interface{}](ctx context.Context, c *Client, d *schema.ResourceData, endpoint string) error {
+	if d.Get("on_destroy").(string) != RestorePrevious.String() {
+		return nil
+	}
+
+	previousSettings, err := DoRequest[B, B](ctx, c, GET, endpoint, nil)
+	if err != nil {
+		return err
+	}
+
+	return setPreviousSettings(ctx, d, previousSettings)
+}
+
+func setPreviousSettings(ctx context.Context, d *schema.ResourceData, previousSettings interface{}) error {
+	encodedSettings, err := json.Marshal(previousSettings)
+	if err != nil {
+		return err
+	}
+
+	tflog.Debug(ctx, "Recorded settings prior to create")
+
+	return d.Set("previous_settings", string(encodedSettings))
+}
+
+func destroySingletonSettings[B interface{}](ctx context.Context, c *Client, d *schema.ResourceData, endpoint string, defaults *B) diag.Diagnostics {
+	return destroySettings(ctx, d, endpoint, defaults, func(settings B) error {
+		_, err := DoRequest[B, B](ctx, c, PUT, endpoint, &settings)
+		return err
+	})
+}
+
+// Applies on_destroy to the settings which put writes to the cluster. Settings without defaults are
+// retained when they would be reset.
+func destroySettings[B interface{}](ctx context.Context, d *schema.ResourceData, description string, defaults *B, put func(B) error) diag.Diagnostics {
+	switch d.Get("on_destroy").(string) {
+	case ResetToDefaults.String():
+		if defaults == nil {
+			return diag.Diagnostics{
+				diag.Diagnostic{
+					Severity: diag.Warning,
+					Summary:  "No default settings",
+					Detail:   fmt.Sprintf("on_destroy is %q but %s has no defaults; the current settings were retained", ResetToDefaults, description),
+				},
+			}
+		}
+
+		tflog.Info(ctx, "Resetting settings to defaults", map[string]interface{}{
+			"settings": description,
+		})
+
+		return diag.FromErr(put(*defaults))
+	case RestorePrevious.String():
+		return restorePreviousSettings(ctx, d, description, put)
+	default:
+		tflog.Info(ctx, "Retaining settings on the cluster", map[string]interface{}{
+			"settings": description,
+		})
+
+		return nil
+	}
+}
+
+func restorePreviousSettings[B interface{}](ctx context.Context, d *schema.ResourceData, description string, put func(B) error) diag.Diagnostics {
+	encodedSettings := d.Get("previous_settings").(string)
+	if encodedSettings == "" {
+		// Imported resources were never created by Terraform, and resources created with another
+		// on_destroy didn't record their settings, so there is nothing to go back to
+		return diag.Diagnostics{
+			diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  "No previous settings recorded",
+				Detail:   fmt.Sprintf("on_destroy is %q but no settings were recorded when this resource was created; the current settings at %s were retained", RestorePrevious, description),
+			},
+		}
+	}
+
+	var previousSettings B
+	if err := json.Unmarshal([]byte(encodedSettings), &previousSettings); err != nil {
+		return diag.FromErr(fmt.Errorf("unable to decode previous settings: %w", err))
+	}
+
+	tflog.Info(ctx, "Restoring settings recorded prior to create", map[string]interface{}{
+		"settings": description,
+	})
+
+	return diag.FromErr(put(previousSettings))
+}
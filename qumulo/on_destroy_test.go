@@ -0,0 +1,27 @@
+package qumulo
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// Decodes the settings a resource recorded for on_destroy = "restore_previous", so that CheckDestroy can
+// compare them with what the cluster is left with
+func testAccReadPreviousSettings[B any](name string, previousSettings *B) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", name)
+		}
+
+		encoded := rs.Primary.Attributes["previous_settings"]
+		if encoded == "" {
+			return fmt.Errorf("expected %s to record its previous settings", name)
+		}
+
+		return json.Unmarshal([]byte(encoded), previousSettings)
+	}
+}
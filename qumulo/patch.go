@@ -0,0 +1,56 @@
+package qumulo
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Reduces a full request body to a JSON merge patch (RFC 7386) holding only the fields whose attributes
+// changed, so fields the provider doesn't model are left as they are on the cluster. Fields are matched
+// to the attribute of the same name unless they are listed in attributes.
+func mergePatch[B any](d *schema.ResourceData, body B, attributes map[string][]string) (map[string]interface{}, error) {
+	rb, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields map[string]interface{}
+	if err := json.Unmarshal(rb, &fields); err != nil {
+		return nil, err
+	}
+
+	patch := map[string]interface{}{}
+	for field, value := range fields {
+		keys, ok := attributes[field]
+		if !ok {
+			keys = []string{field}
+		}
+
+		if d.HasChanges(keys...) {
+			patch[field] = value
+		}
+	}
+
+	return patch, nil
+}
+
+// PATCHes the changed fields of body, skipping the request if none of them changed
+func doMergePatch[B any, R any](ctx context.Context, c *Client, d *schema.ResourceData, endpointUri string, body B, attributes map[string][]string) error {
+	patch, err := mergePatch(d, body, attributes)
+	if err != nil {
+		return err
+	}
+
+	if len(patch) == 0 {
+		tflog.Debug(ctx, "No changed fields to send", map[string]interface{}{
+			"endpoint": endpointUri,
+		})
+		return nil
+	}
+
+	_, err = DoRequest[map[string]interface{}, R](ctx, c, PATCH, endpointUri, &patch)
+	return err
+}
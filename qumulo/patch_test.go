@@ -0,0 +1,36 @@
+package qumulo
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestMergePatch(t *testing.T) {
+	type body struct {
+		Enabled  bool   `json:"enabled"`
+		Greeting string `json:"greeting"`
+		Password string `json:"password"`
+		Unknown  string `json:"unmodelled_field"`
+	}
+
+	d := schema.TestResourceDataRaw(t, map[string]*schema.Schema{
+		"enabled":     {Type: schema.TypeBool, Optional: true},
+		"greeting":    {Type: schema.TypeString, Optional: true},
+		"password_wo": {Type: schema.TypeString, Optional: true},
+	}, map[string]interface{}{
+		"greeting":    "Hello!",
+		"password_wo": "Test1234",
+	})
+
+	patch, err := mergePatch(d, body{Greeting: "Hello!", Password: "Test1234"}, map[string][]string{
+		"password": {"password_wo"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error building patch: %v", err)
+	}
+
+	if len(patch) != 2 || patch["greeting"] != "Hello!" || patch["password"] != "Test1234" {
+		t.Errorf("expected the patch to only hold the changed greeting and password, got %v", patch)
+	}
+}
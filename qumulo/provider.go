@@ -2,6 +2,10 @@ package qumulo
 
 import (
 	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -11,68 +15,111 @@ func Provider() *schema.Provider {
 	return &schema.Provider{
 		Schema: map[string]*schema.Schema{
 			"host": &schema.Schema{
-				Type:        schema.TypeString,
-				Optional:    true,
-				DefaultFunc: schema.EnvDefaultFunc("QUMULO_HOST", nil),
+				Type:     schema.TypeString,
+				Optional: true,
 			},
 			"port": &schema.Schema{
-				Type:        schema.TypeString,
-				Optional:    true,
-				DefaultFunc: schema.EnvDefaultFunc("QUMULO_PORT", nil),
+				Type:     schema.TypeString,
+				Optional: true,
 			},
 			"username": &schema.Schema{
-				Type:        schema.TypeString,
-				Optional:    true,
-				DefaultFunc: schema.EnvDefaultFunc("QUMULO_USERNAME", nil),
+				Type:     schema.TypeString,
+				Optional: true,
 			},
 			"password": &schema.Schema{
-				Type:        schema.TypeString,
-				Optional:    true,
-				Sensitive:   true,
-				DefaultFunc: schema.EnvDefaultFunc("QUMULO_PASSWORD", nil),
+				Type:      schema.TypeString,
+				Optional:  true,
+				Sensitive: true,
+			},
+			"read_only": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
 			},
 		},
 		ResourcesMap: map[string]*schema.Resource{
-			"qumulo_cluster_name":            resourceClusterSettings(),
-			"qumulo_ad_settings":             resourceActiveDirectory(),
-			"qumulo_ldap_server":             resourceLdapServer(),
-			"qumulo_ssl_cert":                resourceSsl(),
-			"qumulo_ssl_ca":                  resourceSslCa(),
-			"qumulo_monitoring":              resourceMonitoring(),
+			"qumulo_cluster_name":            singletonResource("qumulo_cluster_name", resourceClusterSettings()),
+			"qumulo_ad_settings":             singletonResource("qumulo_ad_settings", resourceActiveDirectory()),
+			"qumulo_ldap_server":             singletonResource("qumulo_ldap_server", resourceLdapServer()),
+			"qumulo_ssl_cert":                singletonResource("qumulo_ssl_cert", resourceSsl()),
+			"qumulo_ssl_ca":                  singletonResource("qumulo_ssl_ca", resourceSslCa()),
+			"qumulo_monitoring":              singletonResource("qumulo_monitoring", resourceMonitoring()),
 			"qumulo_nfs_export":              resourceNfsExport(),
-			"qumulo_nfs_settings":            resourceNfsSettings(),
-			"qumulo_smb_server":              resourceSmbServer(),
+			"qumulo_nfs_settings":            singletonResource("qumulo_nfs_settings", resourceNfsSettings()),
+			"qumulo_smb_server":              singletonResource("qumulo_smb_server", resourceSmbServer()),
 			"qumulo_smb_share":               resourceSmbShare(),
 			"qumulo_role":                    resourceRole(),
-			"qumulo_time_configuration":      resourceTimeConfiguration(),
+			"qumulo_time_configuration":      singletonResource("qumulo_time_configuration", resourceTimeConfiguration()),
 			"qumulo_directory_quota":         resourceDirectoryQuota(),
 			"qumulo_local_user":              resourceUser(),
 			"qumulo_local_group":             resourceGroup(),
-			"qumulo_web_ui":                  resourceWebUi(),
-			"qumulo_file_system_settings":    resourceFileSystemSettings(),
+			"qumulo_web_ui":                  singletonResource("qumulo_web_ui", resourceWebUi()),
+			"qumulo_file_system_settings":    singletonResource("qumulo_file_system_settings", resourceFileSystemSettings()),
 			"qumulo_interface_configuration": resourceInterfaceConfiguration(),
 			"qumulo_network_configuration":   resourceNetworkConfiguration(),
-			"qumulo_ftp_server":              resourceFtpServer(),
+			"qumulo_ftp_server":              singletonResource("qumulo_ftp_server", resourceFtpServer()),
 			"qumulo_local_group_member":      resourceGroupMember(),
-			"qumulo_syslog":                  resourceSyslog(),
-			"qumulo_cloudwatch":              resourceCloudWatch(),
-			"qumulo_role_member":             resourceRoleMember(),
+			"qumulo_syslog":                  singletonResource("qumulo_syslog", resourceSyslog()),
+			"qumulo_cloudwatch":              singletonResource("qumulo_cloudwatch", resourceCloudWatch()),
+		},
+		DataSourcesMap: map[string]*schema.Resource{
+			"qumulo_directory_quotas":  dataSourceDirectoryQuotas(),
+			"qumulo_ad_status":         dataSourceActiveDirectoryStatus(),
+			"qumulo_ldap_status":       dataSourceLdapStatus(),
+			"qumulo_snapshots":         dataSourceSnapshots(),
+			"qumulo_snapshot_policies": dataSourceSnapshotPolicies(),
+			"qumulo_time_status":       dataSourceTimeStatus(),
+			"qumulo_nodes":             dataSourceNodes(),
 		},
 		ConfigureContextFunc: providerConfigure,
 	}
 }
 
 func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
+	return configureClient(ctx, d, newDefaultTransport())
+}
 
-	host := d.Get("host").(string)
-	port := d.Get("port").(string)
-	username := d.Get("username").(string)
-	password := d.Get("password").(string)
+func configureClient(ctx context.Context, d *schema.ResourceData, transport http.RoundTripper) (interface{}, diag.Diagnostics) {
+	host := providerSetting(d.Get("host").(string), "QUMULO_HOST")
+	port := providerSetting(d.Get("port").(string), "QUMULO_PORT")
+	username := providerSetting(d.Get("username").(string), "QUMULO_USERNAME")
+	password := providerSetting(d.Get("password").(string), "QUMULO_PASSWORD")
+	readOnly, err := providerReadOnly(d.Get("read_only").(bool))
+	if err != nil {
+		return nil, diag.FromErr(err)
+	}
 
-	c, err := NewClient(ctx, &host, &port, &username, &password)
+	c, err := NewClientWithTransport(ctx, &host, &port, &username, &password, transport)
 	if err != nil {
 		return nil, diag.FromErr(err)
 	}
+	c.ReadOnly = readOnly
 
 	return c, nil
 }
+
+// Settings which aren't configured are read from the environment. This isn't done with a DefaultFunc, since
+// the configuration Provider() validates has to be the same as the one FrameworkProvider validates.
+func providerSetting(value, key string) string {
+	if value == "" {
+		return os.Getenv(key)
+	}
+	return value
+}
+
+// The provider is read only when either read_only or QUMULO_READ_ONLY is true, so that setting one of them
+// is enough to be certain that nothing is modified
+func providerReadOnly(value bool) (bool, error) {
+	if value {
+		return true, nil
+	}
+	env := os.Getenv("QUMULO_READ_ONLY")
+	if env == "" {
+		return false, nil
+	}
+
+	readOnly, err := strconv.ParseBool(env)
+	if err != nil {
+		return false, fmt.Errorf("expected QUMULO_READ_ONLY to be true or false, got %q", env)
+	}
+	return readOnly, nil
+}
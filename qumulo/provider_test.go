@@ -1,22 +1,48 @@
 package qumulo
 
 import (
+	"context"
+	"fmt"
 	"math/rand"
+	"net/http"
 	"os"
+	"regexp"
 	"testing"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
-var testAccProviders map[string]*schema.Provider
+var testAccProtoV6ProviderFactories map[string]func() (tfprotov6.ProviderServer, error)
 var testAccProvider *schema.Provider
 
 func init() {
 	testAccProvider = Provider()
-	testAccProviders = map[string]*schema.Provider{
-		"qumulo": testAccProvider,
+	testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
+		"qumulo": func() (tfprotov6.ProviderServer, error) {
+			providerServer, err := newProviderServer(context.Background(), testAccProvider, &FrameworkProvider{
+				newTransport: testAccTransport,
+			})
+			if err != nil {
+				return nil, err
+			}
+			return providerServer(), nil
+		},
 	}
+
+	testAccProvider.ConfigureContextFunc = func(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
+		return configureClient(ctx, d, testAccTransport())
+	}
+}
+
+func testAccTransport() http.RoundTripper {
+	if testAccCassette != nil {
+		return testAccCassette
+	}
+	return newDefaultTransport()
 }
 
 var letters = []rune("abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ")
@@ -30,7 +56,25 @@ func randSeq(n int) string {
 	return string(b)
 }
 
+// The SDK records unset numbers as 0 in the state
+func testAccStateNumber(v string) string {
+	if v == "" {
+		return "0"
+	}
+	return v
+}
+
 func testAccPreCheck(t *testing.T) {
+	useCassette(t)
+	if replayingCassette(t) {
+		return
+	}
+
+	if usingFakeCluster() {
+		startFakeCluster(t)
+		return
+	}
+
 	if v := os.Getenv("QUMULO_HOST"); v == "" {
 		t.Fatal("QUMULO_HOST must be set for acceptance tests")
 	}
@@ -44,3 +88,78 @@ func testAccPreCheck(t *testing.T) {
 		t.Fatal("QUMULO_PASSWORD must be set for acceptance tests")
 	}
 }
+
+// A read only provider refreshes and plans existing resources of both the SDK and the plugin framework
+// provider, and refuses to apply changes to either
+func TestAccReadOnlyProvider(t *testing.T) {
+	readOnlyRole := roleActors2
+	readOnlyRole.Name = role1.Name
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRoleMemberConfig(role1, userRoles1),
+			},
+			{
+				Config:   testAccReadOnlyProviderConfig + testAccRoleMemberConfig(role1, userRoles1),
+				PlanOnly: true,
+			},
+			{
+				// Updates qumulo_role
+				Config:      testAccReadOnlyProviderConfig + testAccRoleMemberConfig(readOnlyRole, userRoles1),
+				ExpectError: regexp.MustCompile(`PUT /v1/auth/roles/\S+ was refused because\s+the\s+provider\s+is\s+read\s+only`),
+			},
+			{
+				// Deletes qumulo_role_member
+				Config: testAccReadOnlyProviderConfig + fmt.Sprintf(`
+resource "qumulo_role" "test_role" {
+	name        = %q
+	description = %q
+	privileges  = %v
+}
+`, role1.Name, role1.Description, PrintTerraformListFromList(role1.Privileges)),
+				ExpectError: regexp.MustCompile(`DELETE /v1/auth/roles/\S+ was refused because\s+the\s+provider\s+is\s+read\s+only`),
+			},
+			{
+				Config:   testAccRoleMemberConfig(role1, userRoles1),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+const testAccReadOnlyProviderConfig = `
+provider "qumulo" {
+	read_only = true
+}
+`
+
+func TestProviderReadOnly(t *testing.T) {
+	for _, tc := range []struct {
+		value    bool
+		env      string
+		expected bool
+	}{
+		{false, "", false},
+		{true, "", true},
+		{false, "true", true},
+		{false, "1", true},
+		{true, "false", true},
+		{false, "false", false},
+	} {
+		t.Setenv("QUMULO_READ_ONLY", tc.env)
+		readOnly, err := providerReadOnly(tc.value)
+		if err != nil {
+			t.Errorf("unexpected error with read_only %v and QUMULO_READ_ONLY %q: %v", tc.value, tc.env, err)
+		} else if readOnly != tc.expected {
+			t.Errorf("expected read_only %v and QUMULO_READ_ONLY %q to be read only: %v, got %v", tc.value, tc.env, tc.expected, readOnly)
+		}
+	}
+
+	t.Setenv("QUMULO_READ_ONLY", "yes please")
+	if _, err := providerReadOnly(false); err == nil {
+		t.Errorf("expected an error with an invalid QUMULO_READ_ONLY")
+	}
+}
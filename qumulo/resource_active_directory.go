@@ -3,10 +3,11 @@ package qumulo
 import (
 	"context"
 	"fmt"
-	"strconv"
+	"os"
 	"strings"
 	"time"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -103,9 +104,9 @@ type ActiveDirectoryLdapStates struct {
 }
 
 type ActiveDirectoryRequest struct {
-	Settings      *ActiveDirectorySettingsBody
-	JoinSettings  *ActiveDirectoryJoinRequest
-	UsageSettings *ActiveDirectoryUsageSettingsRequest
+	Settings            *ActiveDirectorySettingsBody
+	JoinSettings        *ActiveDirectoryJoinRequest
+	ReconfigureSettings *ActiveDirectoryReconfigureRequest
 }
 
 type ActiveDirectoryResponse struct {
@@ -114,7 +115,7 @@ type ActiveDirectoryResponse struct {
 }
 
 type ActiveDirectoryMonitorLastError struct {
-	Module      string `json"module"`
+	Module      string `json:"module"`
 	ErrorClass  string `json:"error_class"`
 	Description string `json:"description"`
 	Stack       string `json:"stack"`
@@ -136,15 +137,24 @@ type ActiveDirectoryMonitorResponse struct {
 	DomainNetBios        string                          `json:"domain_netbios"`
 }
 
-type ActiveDirectoryUsageSettingsRequest struct {
+// Moving the computer account to another OU needs the join credentials, other changes don't
+type ActiveDirectoryReconfigureRequest struct {
+	Domain               string `json:"domain"`
+	User                 string `json:"user,omitempty"`
+	Password             string `json:"password,omitempty"`
+	Ou                   string `json:"ou"`
 	UseAdPosixAttributes bool   `json:"use_ad_posix_attributes"`
 	BaseDn               string `json:"base_dn"`
 }
 
+type ActiveDirectoryPreferredDcsBody struct {
+	Dcs []string `json:"dcs"`
+}
+
 type ActiveDirectoryLeaveRequest struct {
 	Domain   string `json:"domain"`
-	User     string `json:"user"`
-	Password string `json:"password"`
+	User     string `json:"user,omitempty"`
+	Password string `json:"password,omitempty"`
 }
 
 const AdSettingsEndpoint = "/v1/ad/settings"
@@ -153,12 +163,17 @@ const AdJoinEndpoint = "/v1/ad/join"
 const AdMonitorEndpoint = "/v1/ad/monitor"
 const AdReconfigureEndpoint = "/v1/ad/reconfigure"
 const AdLeaveEndpoint = "/v1/ad/leave"
+const AdPreferredDcsEndpoint = "/v1/ad/dcs"
 
-const AdJoinWaitTime = 1 * time.Second
-const AdJoinTimeoutIterations = 120
+// Terraform doesn't send the configuration when destroying a resource, so the join password used to
+// remove the computer account when leaving the domain is usually taken from here
+const AdPasswordEnvVar = "QUMULO_AD_PASSWORD"
+
+// Joins usually finish within a few seconds, so the monitor is polled quickly at first
+const AdMonitorMinInterval = 500 * time.Millisecond
 
 func resourceActiveDirectory() *schema.Resource {
-	return &schema.Resource{
+	r := &schema.Resource{
 		CreateContext: resourceActiveDirectoryCreate,
 		ReadContext:   resourceActiveDirectoryRead,
 		UpdateContext: resourceActiveDirectoryUpdate,
@@ -184,18 +199,29 @@ func resourceActiveDirectory() *schema.Resource {
 			"ad_username": &schema.Schema{
 				Type:     schema.TypeString,
 				Required: true,
-				ForceNew: true,
 			},
 			"ad_password": &schema.Schema{
-				Type:      schema.TypeString,
-				Required:  true,
-				ForceNew:  true,
-				Sensitive: true,
+				Type:             schema.TypeString,
+				Optional:         true,
+				Sensitive:        true,
+				DiffSuppressFunc: suppressSecretHashDiff,
+				ExactlyOneOf:     []string{"ad_password", "ad_password_wo"},
 			},
+			"ad_password_wo":      writeOnlySecretSchema("ad_password"),
+			"ad_password_version": secretVersionSchema(),
 			"ou": &schema.Schema{
 				Type:     schema.TypeString,
 				Optional: true,
-				ForceNew: true,
+			},
+			// Left out of the configuration, the cluster's preferred domain controllers are left alone
+			"preferred_dcs": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type:             schema.TypeString,
+					ValidateDiagFunc: validation.ToDiagFunc(validateHostOrIpAddress),
+				},
 			},
 			"use_ad_posix_attributes": &schema.Schema{
 				Type:     schema.TypeBool,
@@ -223,11 +249,32 @@ func resourceActiveDirectory() *schema.Resource {
 				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(ActiveDirectoryCryptoValues, false)),
 				Default:          WantCrypto.String(),
 			},
+			"join_status": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"dcs": activeDirectoryDcsSchema(),
 		},
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+	}
+
+	// Version 0 states were identified by the Unix time at which they were created, and had no write-only
+	// password, domain controllers or join status. singletonResource leaves this upgrader in place.
+	r.SchemaVersion = 1
+	r.StateUpgraders = []schema.StateUpgrader{
+		{
+			Version: 0,
+			Type: earlierStateType(r, map[string]cty.Type{
+				"ad_password_version": cty.NilType,
+				"ad_password_wo":      cty.NilType,
+				"preferred_dcs":       cty.NilType,
+				"join_status":         cty.NilType,
+				"dcs":                 cty.NilType,
+			}),
+			Upgrade: upgradeWithDefaults(r, upgradeSingletonIdV0),
 		},
 	}
+
+	return r
 }
 
 func resourceActiveDirectoryCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -243,7 +290,7 @@ func resourceActiveDirectoryCreate(ctx context.Context, d *schema.ResourceData,
 		Domain:               d.Get("domain").(string),
 		DomainNetBios:        d.Get("domain_netbios").(string),
 		User:                 d.Get("ad_username").(string),
-		Password:             d.Get("ad_password").(string),
+		Password:             adPassword(d),
 		Ou:                   d.Get("ou").(string),
 		UseAdPosixAttributes: d.Get("use_ad_posix_attributes").(bool),
 		BaseDn:               d.Get("base_dn").(string),
@@ -260,7 +307,19 @@ func resourceActiveDirectoryCreate(ctx context.Context, d *schema.ResourceData,
 		return diag.FromErr(err)
 	}
 
-	d.SetId(strconv.FormatInt(time.Now().Unix(), 10))
+	if err := setSingletonId(ctx, d, m); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if v, ok := d.GetOk("preferred_dcs"); ok {
+		if err := setPreferredDcs(ctx, c, v.([]interface{})); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if err := setSecretHash(d, "ad_password", getSecret(d, "ad_password")); err != nil {
+		return diag.FromErr(err)
+	}
 
 	return resourceActiveDirectoryRead(ctx, d, m)
 }
@@ -298,6 +357,16 @@ func resourceActiveDirectoryRead(ctx context.Context, d *schema.ResourceData, m
 	errs.addMaybeError(d.Set("use_ad_posix_attributes", adStatus.UseAdPosixAttributes))
 	errs.addMaybeError(d.Set("base_dn", adStatus.BaseDn))
 	errs.addMaybeError(d.Set("domain_netbios", adStatus.DomainNetBios))
+	errs.addMaybeError(d.Set("join_status", adStatus.Status))
+	errs.addMaybeError(d.Set("dcs", flattenActiveDirectoryDcs(adStatus.Dcs)))
+	errs.addMaybeError(setSecretHash(d, "ad_password", d.Get("ad_password").(string)))
+
+	preferredDcs, err := DoRequest[ActiveDirectoryPreferredDcsBody, ActiveDirectoryPreferredDcsBody](ctx, c, GET, AdPreferredDcsEndpoint, nil)
+	if err != nil {
+		return append(errs.diags, diag.FromErr(err)...)
+	}
+	errs.addMaybeError(d.Set("preferred_dcs", preferredDcs.Dcs))
+
 	return errs.diags
 }
 
@@ -310,14 +379,21 @@ func resourceActiveDirectoryUpdate(ctx context.Context, d *schema.ResourceData,
 		Crypto:  d.Get("crypto").(string),
 	}
 
-	updatedUsageSettings := ActiveDirectoryUsageSettingsRequest{
+	updatedReconfigureSettings := ActiveDirectoryReconfigureRequest{
+		Domain:               d.Get("domain").(string),
+		Ou:                   d.Get("ou").(string),
 		UseAdPosixAttributes: d.Get("use_ad_posix_attributes").(bool),
 		BaseDn:               d.Get("base_dn").(string),
 	}
 
+	if d.HasChange("ou") {
+		updatedReconfigureSettings.User = d.Get("ad_username").(string)
+		updatedReconfigureSettings.Password = adPassword(d)
+	}
+
 	updatedAdRequest := ActiveDirectoryRequest{
-		Settings:      &updatedAdSettings,
-		UsageSettings: &updatedUsageSettings,
+		Settings:            &updatedAdSettings,
+		ReconfigureSettings: &updatedReconfigureSettings,
 	}
 
 	tflog.Debug(ctx, "Updating Active Directory settings")
@@ -326,6 +402,21 @@ func resourceActiveDirectoryUpdate(ctx context.Context, d *schema.ResourceData,
 		return diag.FromErr(err)
 	}
 
+	if d.HasChange("preferred_dcs") {
+		if err := setPreferredDcs(ctx, c, d.Get("preferred_dcs").([]interface{})); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	// The join credentials are only used when joining, moving the computer account or leaving, so
+	// rotating them, including a new ad_password_wo through ad_password_version, just records the new
+	// password
+	if d.HasChange("ad_password") {
+		if err := setSecretHash(d, "ad_password", getSecret(d, "ad_password")); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	return resourceActiveDirectoryRead(ctx, d, m)
 }
 
@@ -333,9 +424,17 @@ func resourceActiveDirectoryDelete(ctx context.Context, d *schema.ResourceData,
 	c := m.(*Client)
 
 	leaveAdSettings := ActiveDirectoryLeaveRequest{
-		Domain:   d.Get("domain").(string),
-		User:     d.Get("ad_username").(string),
-		Password: d.Get("ad_password").(string),
+		Domain: d.Get("domain").(string),
+	}
+	// Only a hash of the join password is kept in state, so the computer account can only be removed
+	// when the password is in the configuration or the environment
+	password := adPassword(d)
+	if password == "" {
+		password = os.Getenv(AdPasswordEnvVar)
+	}
+	if password != "" {
+		leaveAdSettings.User = d.Get("ad_username").(string)
+		leaveAdSettings.Password = password
 	}
 
 	_, err := DoRequest[ActiveDirectoryLeaveRequest, ActiveDirectoryMonitorResponse](ctx, c, POST, AdLeaveEndpoint, &leaveAdSettings)
@@ -349,7 +448,26 @@ func resourceActiveDirectoryDelete(ctx context.Context, d *schema.ResourceData,
 		return diag.FromErr(err)
 	}
 
-	return nil
+	if leaveAdSettings.Password != "" {
+		return nil
+	}
+
+	return diag.Diagnostics{{
+		Severity: diag.Warning,
+		Summary:  "Computer account not removed",
+		Detail: fmt.Sprintf("The cluster left %q without credentials, so its computer account has to be "+
+			"removed from Active Directory manually. Set %s to the join password to remove it when leaving.",
+			leaveAdSettings.Domain, AdPasswordEnvVar),
+	}}
+}
+
+// The join password may come from either the hashed or the write-only argument
+func adPassword(d *schema.ResourceData) string {
+	if v := getSecret(d, "ad_password"); v != "" {
+		return v
+	}
+
+	return getSecret(d, "ad_password_wo")
 }
 
 func createActiveDirectory(ctx context.Context, c *Client, clusterReq ActiveDirectoryRequest) (*ActiveDirectoryResponse, error) {
@@ -377,8 +495,8 @@ func updateActiveDirectory(ctx context.Context, c *Client, clusterReq ActiveDire
 	var joinResponsePointer *ActiveDirectoryJoinResponse
 	var err error
 
-	if d.HasChanges("use_ad_posix_attributes", "base_dn") {
-		joinResponsePointer, err = updateActiveDirectoryUsage(ctx, c, clusterReq.UsageSettings)
+	if d.HasChanges("ou", "use_ad_posix_attributes", "base_dn") {
+		joinResponsePointer, err = reconfigureActiveDirectory(ctx, c, clusterReq.ReconfigureSettings)
 		if err != nil {
 			return nil, err
 		}
@@ -443,13 +561,13 @@ func joinActiveDirectory(ctx context.Context, c *Client, joinRequest *ActiveDire
 	return joinResponse, nil
 }
 
-func updateActiveDirectoryUsage(ctx context.Context, c *Client, usageRequest *ActiveDirectoryUsageSettingsRequest) (*ActiveDirectoryJoinResponse, error) {
-	if usageRequest == nil {
+func reconfigureActiveDirectory(ctx context.Context, c *Client, reconfigureRequest *ActiveDirectoryReconfigureRequest) (*ActiveDirectoryJoinResponse, error) {
+	if reconfigureRequest == nil {
 		tflog.Debug(ctx, " No updated Active Directory usage settings detected, will not apply changes.")
 		return nil, nil
 	}
 
-	usageUpdateResponse, err := DoRequest[ActiveDirectoryUsageSettingsRequest, ActiveDirectoryJoinResponse](ctx, c, POST, AdReconfigureEndpoint, usageRequest)
+	usageUpdateResponse, err := DoRequest[ActiveDirectoryReconfigureRequest, ActiveDirectoryJoinResponse](ctx, c, POST, AdReconfigureEndpoint, reconfigureRequest)
 	if err != nil {
 		return nil, err
 	}
@@ -462,34 +580,38 @@ func updateActiveDirectoryUsage(ctx context.Context, c *Client, usageRequest *Ac
 	return usageUpdateResponse, nil
 }
 
-func waitForADMonitorUpdate(ctx context.Context, c *Client) error {
-
-	var finishedJoinStatus *ActiveDirectoryMonitorResponse
-
-	joinCompleted := false
-	numIterations := 0
+func setPreferredDcs(ctx context.Context, c *Client, dcs []interface{}) error {
+	preferredDcs := ActiveDirectoryPreferredDcsBody{
+		Dcs: InterfaceSliceToStringSlice(dcs),
+	}
 
-	for !joinCompleted {
-		joinStatus, err := DoRequest[ActiveDirectoryMonitorResponse, ActiveDirectoryMonitorResponse](ctx, c, GET, AdMonitorEndpoint, nil)
-		if err != nil {
-			return err
-		}
+	tflog.Debug(ctx, "Updating preferred domain controllers")
+	_, err := DoRequest[ActiveDirectoryPreferredDcsBody, ActiveDirectoryPreferredDcsBody](ctx, c, PUT, AdPreferredDcsEndpoint, &preferredDcs)
+	return err
+}
 
-		if !strings.Contains(joinStatus.Status, "IN_PROGRESS") {
-			joinCompleted = true
-			finishedJoinStatus = joinStatus
-		} else {
-			tflog.Debug(ctx, "Waiting another second for AD operation to complete.")
-			numIterations++
-			time.Sleep(AdJoinWaitTime)
-		}
+func waitForADMonitorUpdate(ctx context.Context, c *Client) error {
+	waiter := Waiter[ActiveDirectoryMonitorResponse]{
+		Operation: "Active Directory operation",
+		Refresh: func(ctx context.Context) (*ActiveDirectoryMonitorResponse, string, error) {
+			monitor, err := DoRequest[ActiveDirectoryMonitorResponse, ActiveDirectoryMonitorResponse](ctx, c, GET, AdMonitorEndpoint, nil)
+			if err != nil {
+				return nil, "", err
+			}
+			return monitor, monitor.Status, nil
+		},
+		Pending: func(state string) bool {
+			return strings.Contains(state, "IN_PROGRESS")
+		},
+		Target: func(state string) bool {
+			return !strings.Contains(state, "IN_PROGRESS")
+		},
+		MinInterval: AdMonitorMinInterval,
+	}
 
-		// XXX amanning32: remove since the resource itself has a timeout?
-		if numIterations > AdJoinTimeoutIterations {
-			tflog.Error(ctx, "Active Directory operation timed out, exiting")
-			//lint:ignore ST1005 proper nouns should be capitalized
-			return fmt.Errorf("Active Directory operation timed out after %d seconds, aborting", AdJoinTimeoutIterations)
-		}
+	finishedJoinStatus, err := waiter.Wait(ctx)
+	if err != nil {
+		return err
 	}
 
 	if strings.Contains(finishedJoinStatus.Status, "FAILED") {
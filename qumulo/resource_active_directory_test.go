@@ -3,6 +3,7 @@ package qumulo
 import (
 	"context"
 	"fmt"
+	"reflect"
 	"regexp"
 	"testing"
 
@@ -13,8 +14,8 @@ import (
 func TestAccJoinActiveDirectoryFull(t *testing.T) {
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:  func() { testAccPreCheck(t) },
-		Providers: testAccProviders,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccActiveDirectoryConfigFull(defaultActiveDirectoryConfig),
@@ -31,8 +32,8 @@ func TestAccJoinActiveDirectoryFull(t *testing.T) {
 func TestAccJoinActiveDirectoryPartial(t *testing.T) {
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:  func() { testAccPreCheck(t) },
-		Providers: testAccProviders,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccActiveDirectoryConfigFull(testingActiveDirectoryConfigPartialJoin),
@@ -41,7 +42,7 @@ func TestAccJoinActiveDirectoryPartial(t *testing.T) {
 					testAccCheckActiveDirectorySettings(*defaultActiveDirectoryConfig.Settings),
 					testAccCheckActiveDirectoryStatus(*defaultActiveDirectoryConfig.JoinSettings),
 				),
-				// This is treated as an update, which has a force-new update on the Ou field
+				// This is treated as an update, which moves the computer account back to the configured Ou
 				ExpectNonEmptyPlan: true,
 			},
 		},
@@ -51,8 +52,8 @@ func TestAccJoinActiveDirectoryPartial(t *testing.T) {
 func TestAccChangeActiveDirectorySettings(t *testing.T) {
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:  func() { testAccPreCheck(t) },
-		Providers: testAccProviders,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccActiveDirectoryConfigFull(defaultActiveDirectoryConfig),
@@ -74,11 +75,37 @@ func TestAccChangeActiveDirectorySettings(t *testing.T) {
 	})
 }
 
+func TestAccOmitActiveDirectoryPreferredDcs(t *testing.T) {
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccActiveDirectoryConfigPreferredDc(defaultActiveDirectoryConfig, "10.0.0.3"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("qumulo_ad_settings.ad_settings", "preferred_dcs.0", "10.0.0.3"),
+					testAccCheckActiveDirectoryPreferredDcs([]string{"10.0.0.3"}),
+				),
+			},
+			// Updating the other settings leaves the preferred domain controllers set on the cluster
+			{
+				Config: testAccActiveDirectoryConfigFull(testingActiveDirectoryConfigSettings),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckActiveDirectorySettings(*testingActiveDirectoryConfigSettings.Settings),
+					resource.TestCheckResourceAttr("qumulo_ad_settings.ad_settings", "preferred_dcs.0", "10.0.0.3"),
+					testAccCheckActiveDirectoryPreferredDcs([]string{"10.0.0.3"}),
+				),
+			},
+		},
+	})
+}
+
 func TestAccChangeActiveDirectoryStatusForceNew(t *testing.T) {
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:  func() { testAccPreCheck(t) },
-		Providers: testAccProviders,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccActiveDirectoryConfigFull(defaultActiveDirectoryConfig),
@@ -108,8 +135,8 @@ func TestAccChangeActiveDirectoryStatusForceNew(t *testing.T) {
 func TestAccChangeActiveDirectoryStatusReconfigure(t *testing.T) {
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:  func() { testAccPreCheck(t) },
-		Providers: testAccProviders,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccActiveDirectoryConfigFull(defaultActiveDirectoryConfig),
@@ -135,11 +162,91 @@ func TestAccChangeActiveDirectoryStatusReconfigure(t *testing.T) {
 	})
 }
 
+func TestAccRotateActiveDirectoryPassword(t *testing.T) {
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccActiveDirectoryConfigFull(defaultActiveDirectoryConfig),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCompareActiveDirectorySettings(defaultActiveDirectoryConfig),
+					resource.TestCheckResourceAttr("qumulo_ad_settings.ad_settings", "join_status", "JOINED_TO_DOMAIN"),
+				),
+			},
+			{
+				// Rotating the join password is applied in place rather than leaving and re-joining the domain
+				Config: testAccActiveDirectoryConfigFull(testingActiveDirectoryConfigRotatedPassword),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCompareActiveDirectorySettings(testingActiveDirectoryConfigRotatedPassword),
+					testAccCheckActiveDirectoryStatus(*testingActiveDirectoryConfigRotatedPassword.JoinSettings),
+				),
+			},
+		},
+	})
+}
+
+func TestAccRotateActiveDirectoryWriteOnlyPassword(t *testing.T) {
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccActiveDirectoryConfigWriteOnlyPassword(defaultActiveDirectoryConfig, 1),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("qumulo_ad_settings.ad_settings", "ad_password_version", "1"),
+					resource.TestCheckNoResourceAttr("qumulo_ad_settings.ad_settings", "ad_password_wo"),
+					resource.TestCheckResourceAttr("qumulo_ad_settings.ad_settings", "join_status", "JOINED_TO_DOMAIN"),
+				),
+			},
+			{
+				// A new write-only password doesn't show up in the plan unless ad_password_version changes
+				Config:             testAccActiveDirectoryConfigWriteOnlyPassword(testingActiveDirectoryConfigRotatedPassword, 1),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: false,
+			},
+			{
+				Config:             testAccActiveDirectoryConfigWriteOnlyPassword(testingActiveDirectoryConfigRotatedPassword, 2),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: testAccActiveDirectoryConfigWriteOnlyPassword(testingActiveDirectoryConfigRotatedPassword, 2),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("qumulo_ad_settings.ad_settings", "ad_password_version", "2"),
+					testAccCheckActiveDirectoryStatus(*testingActiveDirectoryConfigRotatedPassword.JoinSettings),
+				),
+			},
+		},
+	})
+}
+
+func TestAccLeaveActiveDirectoryWithPassword(t *testing.T) {
+	// The configuration isn't sent when destroying, so the password is taken from the environment
+	t.Setenv(AdPasswordEnvVar, defaultActiveDirectoryConfig.JoinSettings.Password)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheckFakeCluster(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckActiveDirectoryLeftWith(defaultActiveDirectoryConfig.JoinSettings),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccActiveDirectoryConfigFull(defaultActiveDirectoryConfig),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("qumulo_ad_settings.ad_settings", "join_status", "JOINED_TO_DOMAIN"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccChangeActiveDirectorySettingsEmpty(t *testing.T) {
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:  func() { testAccPreCheck(t) },
-		Providers: testAccProviders,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccActiveDirectoryConfigNoSettings(defaultActiveDirectoryConfig),
@@ -156,8 +263,8 @@ func TestAccChangeActiveDirectorySettingsEmpty(t *testing.T) {
 func TestAccChangeActiveDirectorySettingsPartial(t *testing.T) {
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:  func() { testAccPreCheck(t) },
-		Providers: testAccProviders,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccActiveDirectoryConfigFull(defaultActiveDirectoryConfig),
@@ -182,8 +289,8 @@ func TestAccChangeActiveDirectorySettingsPartial(t *testing.T) {
 func TestAccChangeActiveDirectorySettingsInvalid_ExpectError(t *testing.T) {
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:  func() { testAccPreCheck(t) },
-		Providers: testAccProviders,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config:      testAccActiveDirectoryConfigFull(testingActiveDirectoryConfigSettingsInvalid),
@@ -220,6 +327,11 @@ var testingActiveDirectoryConfigSettingsReconfigure = ActiveDirectoryRequest{
 	JoinSettings: &testingActiveDirectoryJoinSettingsConfigFullReconfigure,
 }
 
+var testingActiveDirectoryConfigRotatedPassword = ActiveDirectoryRequest{
+	Settings:     &defaultActiveDirectorySettingsConfig,
+	JoinSettings: &testingActiveDirectoryJoinSettingsConfigRotatedPassword,
+}
+
 var testingActiveDirectoryConfigSettingsInvalid = ActiveDirectoryRequest{
 	Settings:     &testingActiveDirectorySettingsConfigInvalid,
 	JoinSettings: &testingActiveDirectoryJoinSettingsConfigFull,
@@ -274,6 +386,16 @@ var testingActiveDirectoryJoinSettingsConfigFullReconfigure = ActiveDirectoryJoi
 	UseAdPosixAttributes: false,
 }
 
+// changes Password
+var testingActiveDirectoryJoinSettingsConfigRotatedPassword = ActiveDirectoryJoinRequest{
+	Domain:               "ad.eng.qumulo.com",
+	DomainNetBios:        "AD",
+	User:                 "Administrator",
+	Password:             "b",
+	UseAdPosixAttributes: false,
+	BaseDn:               "CN=Users,DC=ad,DC=eng,DC=qumulo,DC=com",
+}
+
 var testingActiveDirectoryJoinSettingsConfigPartial = ActiveDirectoryJoinRequest{
 	Domain:   "ad.eng.qumulo.com",
 	User:     "Administrator",
@@ -300,6 +422,37 @@ func testAccActiveDirectoryConfigFull(req ActiveDirectoryRequest) string {
 		req.JoinSettings.Password, req.JoinSettings.Ou, req.JoinSettings.UseAdPosixAttributes, req.JoinSettings.BaseDn)
 }
 
+func testAccActiveDirectoryConfigPreferredDc(req ActiveDirectoryRequest, preferredDc string) string {
+	return fmt.Sprintf(`
+	resource "qumulo_ad_settings" "ad_settings" {
+		signing = %q
+		sealing = %q
+		crypto = %q
+		domain = %q
+		domain_netbios = %q
+		ad_username = %q
+		ad_password = %q
+		preferred_dcs = [%q]
+		base_dn = %q
+	}
+	`, req.Settings.Signing, req.Settings.Sealing, req.Settings.Crypto, req.JoinSettings.Domain, req.JoinSettings.DomainNetBios, req.JoinSettings.User,
+		req.JoinSettings.Password, preferredDc, req.JoinSettings.BaseDn)
+}
+
+func testAccActiveDirectoryConfigWriteOnlyPassword(req ActiveDirectoryRequest, passwordVersion int) string {
+	return fmt.Sprintf(`
+	resource "qumulo_ad_settings" "ad_settings" {
+		domain = %q
+		domain_netbios = %q
+		ad_username = %q
+		ad_password_wo = %q
+		ad_password_version = %v
+		base_dn = %q
+	}
+	`, req.JoinSettings.Domain, req.JoinSettings.DomainNetBios, req.JoinSettings.User, req.JoinSettings.Password, passwordVersion,
+		req.JoinSettings.BaseDn)
+}
+
 func testAccActiveDirectoryConfigPartialSettings(req ActiveDirectoryRequest) string {
 	return fmt.Sprintf(`
 	resource "qumulo_ad_settings" "ad_settings" {
@@ -348,8 +501,8 @@ func testAccCompareActiveDirectorySettings(adRequest ActiveDirectoryRequest) res
 			adRequest.JoinSettings.DomainNetBios),
 		resource.TestCheckResourceAttr("qumulo_ad_settings.ad_settings", "ad_username",
 			adRequest.JoinSettings.User),
-		resource.TestCheckResourceAttr("qumulo_ad_settings.ad_settings", "ad_password",
-			adRequest.JoinSettings.Password),
+		resource.TestCheckResourceAttrWith("qumulo_ad_settings.ad_settings", "ad_password",
+			testAccCheckSecretHash(adRequest.JoinSettings.Password)),
 		resource.TestCheckResourceAttr("qumulo_ad_settings.ad_settings", "ou",
 			adRequest.JoinSettings.Ou),
 		resource.TestCheckResourceAttr("qumulo_ad_settings.ad_settings", "use_ad_posix_attributes",
@@ -393,3 +546,36 @@ func testAccCheckActiveDirectoryStatus(adJoinSettingsRequest ActiveDirectoryJoin
 		return nil
 	}
 }
+
+func testAccCheckActiveDirectoryPreferredDcs(expected []string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		c := testAccProvider.Meta().(*Client)
+		ctx := context.Background()
+		preferredDcs, err := DoRequest[ActiveDirectoryPreferredDcsBody, ActiveDirectoryPreferredDcsBody](ctx, c, GET, AdPreferredDcsEndpoint, nil)
+		if err != nil {
+			return err
+		}
+
+		if !reflect.DeepEqual(preferredDcs.Dcs, expected) {
+			return fmt.Errorf("preferred domain controllers mismatch: Expected %v, got %v", expected, preferredDcs.Dcs)
+		}
+		return nil
+	}
+}
+
+// Checks the credentials the fake cluster was last asked to leave the domain with
+func testAccCheckActiveDirectoryLeftWith(joinSettings *ActiveDirectoryJoinRequest) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		leaveRequest, _ := testFakeCluster.object(AdLeaveEndpoint)
+
+		if leaveRequest["domain"] != joinSettings.Domain {
+			return fmt.Errorf("domain mismatch: Expected %v, got %v", joinSettings.Domain, leaveRequest["domain"])
+		}
+		if leaveRequest["user"] != joinSettings.User || leaveRequest["password"] != joinSettings.Password {
+			return fmt.Errorf("expected the cluster to leave with the join credentials of %v, got %v and %v",
+				joinSettings.User, leaveRequest["user"], leaveRequest["password"])
+		}
+
+		return nil
+	}
+}
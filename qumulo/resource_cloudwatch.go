@@ -2,7 +2,6 @@ package qumulo
 
 import (
 	"context"
-	"strconv"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
@@ -12,13 +11,19 @@ import (
 
 const CloudWatchConfigEndpoint = "/v1/audit/cloudwatch/config"
 
-type CloudWatchConfigBody struct {
-	Enabled      bool   `json:"enabled"`
-	LogGroupName string `json:"log_group_name"`
-	Region       string `json:"region"`
+// Settings applied on destroy when on_destroy = "reset_to_defaults"
+var CloudWatchConfigDefaults = CloudWatchConfigBody{
+	Enabled:      false,
+	LogGroupName: "",
+	Region:       "",
 }
 
 func resourceCloudWatch() *schema.Resource {
+	cloudWatchSchema := cloudWatchConfigBodySchema()
+	cloudWatchSchema["on_destroy"] = onDestroySchema(Retain)
+	cloudWatchSchema["previous_settings"] = previousSettingsSchema()
+	cloudWatchSchema["etag"] = etagSchema()
+
 	return &schema.Resource{
 		CreateContext: resourceCloudWatchCreate,
 		ReadContext:   resourceCloudWatchRead,
@@ -31,36 +36,25 @@ func resourceCloudWatch() *schema.Resource {
 			Delete: schema.DefaultTimeout(1 * time.Minute),
 		},
 
-		Schema: map[string]*schema.Schema{
-			"enabled": &schema.Schema{
-				Type:     schema.TypeBool,
-				Required: true,
-			},
-			"log_group_name": &schema.Schema{
-				Type:     schema.TypeString,
-				Optional: true,
-			},
-			"region": &schema.Schema{
-				Type:     schema.TypeString,
-				Optional: true,
-			},
-		},
-
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
-		},
+		Schema: cloudWatchSchema,
 	}
 }
 
 func resourceCloudWatchCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*Client)
 
+	if err := recordPreviousSettings[CloudWatchConfigBody](ctx, c, d, CloudWatchConfigEndpoint); err != nil {
+		return diag.FromErr(err)
+	}
+
 	err := modifyCloudWatchConfig(ctx, c, d, PUT)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	d.SetId(strconv.FormatInt(time.Now().Unix(), 10))
+	if err := setSingletonId(ctx, d, m); err != nil {
+		return diag.FromErr(err)
+	}
 
 	return resourceCloudWatchRead(ctx, d, m)
 }
@@ -68,21 +62,21 @@ func resourceCloudWatchCreate(ctx context.Context, d *schema.ResourceData, m int
 func resourceCloudWatchRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*Client)
 
-	var errs ErrorCollection
-
 	cloudWatchConfig, err := DoRequest[CloudWatchConfigBody, CloudWatchConfigBody](ctx, c, GET, CloudWatchConfigEndpoint, nil)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	errs.addMaybeError(d.Set("enabled", cloudWatchConfig.Enabled))
-	errs.addMaybeError(d.Set("log_group_name", cloudWatchConfig.LogGroupName))
-	errs.addMaybeError(d.Set("region", cloudWatchConfig.Region))
+	if err := readETag(d, m, CloudWatchConfigEndpoint); err != nil {
+		return diag.FromErr(err)
+	}
 
-	return errs.diags
+	return flattenCloudWatchConfigBody(d, cloudWatchConfig)
 }
 
 func resourceCloudWatchUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	expectETag(d, m, CloudWatchConfigEndpoint)
+
 	c := m.(*Client)
 
 	err := modifyCloudWatchConfig(ctx, c, d, PATCH)
@@ -96,15 +90,13 @@ func resourceCloudWatchUpdate(ctx context.Context, d *schema.ResourceData, m int
 func resourceCloudWatchDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	tflog.Info(ctx, "Deleting CloudWatch resource")
 
-	return nil
+	c := m.(*Client)
+
+	return destroySingletonSettings(ctx, c, d, CloudWatchConfigEndpoint, &CloudWatchConfigDefaults)
 }
 
 func modifyCloudWatchConfig(ctx context.Context, c *Client, d *schema.ResourceData, method Method) error {
-	var config = CloudWatchConfigBody{
-		Enabled:      d.Get("enabled").(bool),
-		LogGroupName: d.Get("log_group_name").(string),
-		Region:       d.Get("region").(string),
-	}
+	config := expandCloudWatchConfigBody(d)
 
 	tflog.Info(ctx, "Updating audit log CloudWatch configuration")
 
@@ -12,8 +12,8 @@ import (
 
 func TestAccCreateCloudWatchAuditLog(t *testing.T) {
 	resource.Test(t, resource.TestCase{
-		PreCheck:  func() { testAccPreCheck(t) },
-		Providers: testAccProviders,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{ // Reset state to default
 				Config: testAccCloudWatchConfig(defaultCloudWatchConfig),
@@ -2,7 +2,6 @@ package qumulo
 
 import (
 	"context"
-	"strconv"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
@@ -13,11 +12,13 @@ import (
 
 const ClusterSettingsEndpoint = "/v1/cluster/settings"
 
-type ClusterSettingsBody struct {
-	ClusterName string `json:"cluster_name"`
-}
-
 func resourceClusterSettings() *schema.Resource {
+	settingsSchema := clusterSettingsBodySchema()
+	// There is no default cluster name to reset to
+	settingsSchema["on_destroy"] = onDestroySchema(Retain, Retain, RestorePrevious)
+	settingsSchema["previous_settings"] = previousSettingsSchema()
+	settingsSchema["etag"] = etagSchema()
+
 	return &schema.Resource{
 		CreateContext: resourceClusterSettingsCreate,
 		ReadContext:   resourceClusterSettingsRead,
@@ -30,26 +31,25 @@ func resourceClusterSettings() *schema.Resource {
 			Delete: schema.DefaultTimeout(1 * time.Minute),
 		},
 
-		Schema: map[string]*schema.Schema{
-			"cluster_name": &schema.Schema{
-				Type:     schema.TypeString,
-				Required: true,
-			},
-		},
-
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
-		},
+		Schema: settingsSchema,
 	}
 }
 
 func resourceClusterSettingsCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+
+	if err := recordPreviousSettings[ClusterSettingsBody](ctx, c, d, ClusterSettingsEndpoint); err != nil {
+		return diag.FromErr(err)
+	}
+
 	err := setClusterSettings(ctx, d, m)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	d.SetId(strconv.FormatInt(time.Now().Unix(), 10))
+	if err := setSingletonId(ctx, d, m); err != nil {
+		return diag.FromErr(err)
+	}
 	return resourceClusterSettingsRead(ctx, d, m)
 }
 
@@ -60,14 +60,16 @@ func resourceClusterSettingsRead(ctx context.Context, d *schema.ResourceData, m
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	if err := d.Set("cluster_name", cs.ClusterName); err != nil {
+	if err := readETag(d, m, ClusterSettingsEndpoint); err != nil {
 		return diag.FromErr(err)
 	}
 
-	return nil
+	return flattenClusterSettingsBody(d, cs)
 }
 
 func resourceClusterSettingsUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	expectETag(d, m, ClusterSettingsEndpoint)
+
 	err := setClusterSettings(ctx, d, m)
 	if err != nil {
 		return diag.FromErr(err)
@@ -79,17 +81,15 @@ func resourceClusterSettingsUpdate(ctx context.Context, d *schema.ResourceData,
 func resourceClusterSettingsDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	tflog.Info(ctx, "Deleting cluster settings resource")
 
-	return nil
+	c := m.(*Client)
+
+	return destroySingletonSettings[ClusterSettingsBody](ctx, c, d, ClusterSettingsEndpoint, nil)
 }
 
 func setClusterSettings(ctx context.Context, d *schema.ResourceData, m interface{}) error {
 	c := m.(*Client)
 
-	clusterName := d.Get("cluster_name").(string)
-
-	cs := ClusterSettingsBody{
-		ClusterName: clusterName,
-	}
+	cs := expandClusterSettingsBody(d)
 
 	tflog.Debug(ctx, "Updating cluster settings")
 	_, err := DoRequest[ClusterSettingsBody, ClusterSettingsBody](ctx, c, PUT, ClusterSettingsEndpoint, &cs)
@@ -15,8 +15,8 @@ func TestAccChangeClusterName(t *testing.T) {
 	rName2 := "Buttercup"
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:  func() { testAccPreCheck(t) },
-		Providers: testAccProviders,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{ // Reset state to default
 				Config: testAccClusterNameConf(defaultName),
@@ -42,6 +42,33 @@ func TestAccChangeClusterName(t *testing.T) {
 				ResourceName:      "qumulo_cluster_name.update_name",
 				ImportState:       true,
 				ImportStateVerify: true,
+				// on_destroy only lives in the configuration
+				ImportStateVerifyIgnore: []string{"on_destroy"},
+			},
+		},
+	})
+}
+
+func TestAccClusterNameRestorePreviousOnDestroy(t *testing.T) {
+	var previousSettings ClusterSettingsBody
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: func(s *terraform.State) error {
+			return testAccCheckClusterName(previousSettings.ClusterName)(s)
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`resource "qumulo_cluster_name" "update_name" {
+	cluster_name = "Vizzini"
+	on_destroy = %q
+}
+`, RestorePrevious),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckClusterName("Vizzini"),
+					testAccReadPreviousSettings("qumulo_cluster_name.update_name", &previousSettings),
+				),
 			},
 		},
 	})
@@ -2,9 +2,12 @@ package qumulo
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"strconv"
 	"time"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -23,7 +26,7 @@ type DirectoryQuotaBody struct {
 type DirectoryQuotaEmptyBody struct{}
 
 func resourceDirectoryQuota() *schema.Resource {
-	return &schema.Resource{
+	r := &schema.Resource{
 		CreateContext: resourceDirectoryQuotaCreate,
 		ReadContext:   resourceDirectoryQuotaRead,
 		UpdateContext: resourceDirectoryQuotaUpdate,
@@ -42,15 +45,70 @@ func resourceDirectoryQuota() *schema.Resource {
 				ForceNew: true,
 			},
 			"limit": &schema.Schema{
-				Type:     schema.TypeString,
-				Required: true,
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateFunc:     validateSize,
+				DiffSuppressFunc: suppressEquivalentSize,
+			},
+			"limit_bytes": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"capacity_used": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
 			},
 		},
 
+		CustomizeDiff: planCanonicalNumber("limit", "limit_bytes", parseSize),
+
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+
+		// Keeps large limits exact when upgrading states
+		UseJSONNumber: true,
+	}
+
+	// The limit was a string of digits in version 0, which had no capacity_used, and a number of bytes in
+	// version 1
+	r.SchemaVersion = 2
+	r.StateUpgraders = []schema.StateUpgrader{
+		{
+			Version: 0,
+			Type: earlierStateType(r, map[string]cty.Type{
+				"limit":         cty.String,
+				"limit_bytes":   cty.NilType,
+				"capacity_used": cty.NilType,
+			}),
+			Upgrade: upgradeWithDefaults(r, upgradeDirectoryQuotaV0),
+		},
+		{
+			Version: 1,
+			Type: earlierStateType(r, map[string]cty.Type{
+				"limit":       cty.Number,
+				"limit_bytes": cty.NilType,
+			}),
+			Upgrade: upgradeWithDefaults(r, upgradeDirectoryQuotaV1),
+		},
+	}
+
+	return r
+}
+
+func upgradeDirectoryQuotaV0(ctx context.Context, rawState map[string]interface{}, m interface{}) (map[string]interface{}, error) {
+	if err := upgradeStringToInt(rawState, "limit"); err != nil {
+		return nil, err
 	}
+	return rawState, nil
+}
+
+func upgradeDirectoryQuotaV1(ctx context.Context, rawState map[string]interface{}, m interface{}) (map[string]interface{}, error) {
+	if limit, ok := rawState["limit"].(json.Number); ok {
+		rawState["limit"] = limit.String()
+		rawState["limit_bytes"] = limit
+	}
+	return rawState, nil
 }
 
 func resourceDirectoryQuotaCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -77,7 +135,28 @@ func resourceDirectoryQuotaRead(ctx context.Context, d *schema.ResourceData, m i
 	}
 
 	errs.addMaybeError(d.Set("directory_id", directoryQuota.Id))
-	errs.addMaybeError(d.Set("limit", directoryQuota.Limit))
+	limit, err := strconv.ParseInt(directoryQuota.Limit, 10, 64)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("unexpected limit %q for quota %s: %w", directoryQuota.Limit, d.Id(), err))
+	}
+	// Keeps the way the limit is written in the configuration unless it changed
+	if current, err := parseSize(d.Get("limit").(string)); err != nil || current != limit {
+		errs.addMaybeError(d.Set("limit", formatSize(limit)))
+	}
+	errs.addMaybeError(d.Set("limit_bytes", limit))
+
+	quotaStatusUrl := DirectoryQuotaStatusEndpoint + d.Id()
+
+	quotaStatus, err := DoRequest[DirectoryQuotaEmptyBody, DirectoryQuotaStatusResponse](ctx, c, GET, quotaStatusUrl, nil)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	capacityUsed, err := strconv.Atoi(quotaStatus.CapacityUsage)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("unexpected capacity usage %q for quota %s: %w", quotaStatus.CapacityUsage, d.Id(), err))
+	}
+	errs.addMaybeError(d.Set("capacity_used", capacityUsed))
 
 	return errs.diags
 }
@@ -113,13 +192,18 @@ func createOrUpdateDirectoryQuota(ctx context.Context, d *schema.ResourceData, m
 
 	directoryId := d.Get("directory_id").(string)
 
+	limit, err := parseSize(d.Get("limit").(string))
+	if err != nil {
+		return err
+	}
+
 	directoryQuotaRequest := DirectoryQuotaBody{
 		Id:    directoryId,
-		Limit: d.Get("limit").(string),
+		Limit: strconv.FormatInt(limit, 10),
 	}
 
 	tflog.Debug(ctx, fmt.Sprintf("Updating directory quota with id %q", directoryId))
-	_, err := DoRequest[DirectoryQuotaBody, DirectoryQuotaBody](ctx, c, method, url, &directoryQuotaRequest)
+	_, err = DoRequest[DirectoryQuotaBody, DirectoryQuotaBody](ctx, c, method, url, &directoryQuotaRequest)
 
 	return err
 }
@@ -3,6 +3,8 @@ package qumulo
 import (
 	"context"
 	"fmt"
+	"net/url"
+	"os"
 	"reflect"
 	"testing"
 
@@ -11,46 +13,94 @@ import (
 )
 
 func TestAccChangeDirectoryQuota(t *testing.T) {
+	directoryId := testAccDirectory(t, TestNamePrefix+"_quota")
+	defaultQuota := DirectoryQuotaBody{Id: directoryId, Limit: defaultDirectoryQuotaLimit}
+	testingQuota := DirectoryQuotaBody{Id: directoryId, Limit: testingDirectoryQuotaLimit}
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:  func() { testAccPreCheck(t) },
-		Providers: testAccProviders,
+		// The pre-check is done by testAccDirectory
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
-				Config: testAccDirectoryQuotaConfig(defaultDirectoryQuota),
+				Config: testAccDirectoryQuotaConfig(defaultQuota, "1GB"),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckDirectoryQuota(defaultDirectoryQuota),
-					testAccCompareDirectoryQuotaSettings(defaultDirectoryQuota),
+					testAccCheckDirectoryQuota(defaultQuota),
+					testAccCompareDirectoryQuotaSettings(defaultQuota, "1GB"),
 				),
 			},
 			{
-				Config: testAccDirectoryQuotaConfig(testingDirectoryQuota),
+				// Sizes of as many bytes as the one in the state don't change it
+				Config:   testAccDirectoryQuotaConfig(defaultQuota, "1000000000"),
+				PlanOnly: true,
+			},
+			{
+				Config: testAccDirectoryQuotaConfig(testingQuota, "2GiB"),
 				Check: resource.ComposeTestCheckFunc(
-					testAccCheckDirectoryQuota(testingDirectoryQuota),
-					testAccCompareDirectoryQuotaSettings(testingDirectoryQuota),
+					testAccCheckDirectoryQuota(testingQuota),
+					testAccCompareDirectoryQuotaSettings(testingQuota, "2GiB"),
 				),
 			},
 		},
 	})
 }
 
-var defaultDirectoryQuota = DirectoryQuotaBody{
-	Id:    "2",
-	Limit: "1000000000",
+const defaultDirectoryQuotaLimit = "1000000000"
+
+const testingDirectoryQuotaLimit = "2147483648"
+
+// Quotas are set on directories the tests create rather than existing ones, so that the sweeper
+// only removes quotas on directories named with TestNamePrefix. The directory left behind by an
+// earlier run is reused, and the directory is removed once the test is done. Returns the directory's
+// file ID.
+func testAccDirectory(t *testing.T, name string) string {
+	// The directory is created before the test case, whose configuration refers to it, so the test
+	// is skipped and checked here rather than by resource.Test
+	if os.Getenv(resource.EnvTfAcc) == "" {
+		t.Skipf("Acceptance tests skipped unless env '%s' set", resource.EnvTfAcc)
+	}
+	testAccPreCheck(t)
+
+	ctx := context.Background()
+	host, port := os.Getenv("QUMULO_HOST"), os.Getenv("QUMULO_PORT")
+	username, password := os.Getenv("QUMULO_USERNAME"), os.Getenv("QUMULO_PASSWORD")
+	c, err := NewClientWithTransport(ctx, &host, &port, &username, &password, testAccTransport())
+	if err != nil {
+		t.Fatalf("unable to sign in to create %s: %v", name, err)
+	}
+
+	createDirectoryUri := FilesEndpoint + url.PathEscape("/") + "/entries/"
+	dir, err := DoRequest[CreateDirectoryRequest, FileAttributesResponse](ctx, c, POST, createDirectoryUri,
+		&CreateDirectoryRequest{Name: name, Action: "CREATE_DIRECTORY"})
+	if err != nil {
+		var existingErr error
+		dir, existingErr = DoRequest[DirectoryQuotaEmptyBody, FileAttributesResponse](ctx, c, GET,
+			FilesEndpoint+url.PathEscape("/"+name)+FileAttributesSuffix, nil)
+		if existingErr != nil {
+			t.Fatalf("unable to create %s: %v", name, err)
+		}
+	}
+
+	t.Cleanup(func() {
+		if _, err := DoRequest[DirectoryQuotaEmptyBody, DirectoryQuotaEmptyBody](ctx, c, DELETE, FilesEndpoint+dir.Id, nil); err != nil {
+			t.Logf("unable to remove %s, which the sweepers don't remove: %v", name, err)
+		}
+	})
+
+	return dir.Id
 }
 
-var testingDirectoryQuota = DirectoryQuotaBody{
-	Id:    "2",
-	Limit: "2000000000",
+type CreateDirectoryRequest struct {
+	Name   string `json:"name"`
+	Action string `json:"action"`
 }
 
-func testAccDirectoryQuotaConfig(req DirectoryQuotaBody) string {
+func testAccDirectoryQuotaConfig(req DirectoryQuotaBody, limit string) string {
 	return fmt.Sprintf(`
 	resource "qumulo_directory_quota" "test_quota" {
 		directory_id = %v
-		limit = %v
+		limit = %q
 	}
-  `, req.Id, req.Limit)
+  `, req.Id, limit)
 }
 
 func testAccCheckDirectoryQuota(quota DirectoryQuotaBody) resource.TestCheckFunc {
@@ -71,11 +121,11 @@ func testAccCheckDirectoryQuota(quota DirectoryQuotaBody) resource.TestCheckFunc
 	}
 }
 
-func testAccCompareDirectoryQuotaSettings(quota DirectoryQuotaBody) resource.TestCheckFunc {
+func testAccCompareDirectoryQuotaSettings(quota DirectoryQuotaBody, limit string) resource.TestCheckFunc {
 	return resource.ComposeTestCheckFunc(
 		resource.TestCheckResourceAttr("qumulo_directory_quota.test_quota", "directory_id",
 			fmt.Sprintf("%v", quota.Id)),
-		resource.TestCheckResourceAttr("qumulo_directory_quota.test_quota", "limit",
-			fmt.Sprintf("%v", quota.Limit)),
+		resource.TestCheckResourceAttr("qumulo_directory_quota.test_quota", "limit", limit),
+		resource.TestCheckResourceAttr("qumulo_directory_quota.test_quota", "limit_bytes", quota.Limit),
 	)
 }
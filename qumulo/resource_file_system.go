@@ -2,7 +2,6 @@ package qumulo
 
 import (
 	"context"
-	"strconv"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
@@ -49,8 +48,14 @@ type FileSystemAtimeSettingsBody struct {
 }
 
 type FileSystemSettingsBody struct {
-	Permissions   *FileSystemPermissionsSettingsBody
-	AtimeSettings *FileSystemAtimeSettingsBody
+	Permissions   *FileSystemPermissionsSettingsBody `json:"permissions"`
+	AtimeSettings *FileSystemAtimeSettingsBody       `json:"atime_settings"`
+}
+
+// Settings applied on destroy when on_destroy = "reset_to_defaults"
+var FileSystemSettingsDefaults = FileSystemSettingsBody{
+	Permissions:   &FileSystemPermissionsSettingsBody{Mode: CrossProtocol.String()},
+	AtimeSettings: &FileSystemAtimeSettingsBody{Enabled: false, Granularity: Hour.String()},
 }
 
 func resourceFileSystemSettings() *schema.Resource {
@@ -84,9 +89,8 @@ func resourceFileSystemSettings() *schema.Resource {
 				Default:          Hour.String(),
 				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(FileSystemAtimeGranularityValues, false)),
 			},
-		},
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			"on_destroy":        onDestroySchema(Retain),
+			"previous_settings": previousSettingsSchema(),
 		},
 	}
 }
@@ -94,12 +98,18 @@ func resourceFileSystemSettings() *schema.Resource {
 func resourceFileSystemSettingsCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*Client)
 
+	if err := c.recordPreviousFileSystemSettings(ctx, d); err != nil {
+		return diag.FromErr(err)
+	}
+
 	err := c.createOrUpdateFileSystemSettings(ctx, d, PUT)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	d.SetId(strconv.FormatInt(time.Now().Unix(), 10))
+	if err := setSingletonId(ctx, d, m); err != nil {
+		return diag.FromErr(err)
+	}
 
 	return resourceFileSystemSettingsRead(ctx, d, m)
 }
@@ -140,7 +150,51 @@ func resourceFileSystemSettingsUpdate(ctx context.Context, d *schema.ResourceDat
 }
 
 func resourceFileSystemSettingsDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	tflog.Info(ctx, "Deleting file system settomgs resource")
+	tflog.Info(ctx, "Deleting file system settings resource")
+
+	c := m.(*Client)
+
+	return destroySettings(ctx, d, "file system settings", &FileSystemSettingsDefaults, func(settings FileSystemSettingsBody) error {
+		return c.putFileSystemSettings(ctx, settings)
+	})
+}
+
+// The settings are spread over two endpoints, so they are recorded together
+func (c *Client) recordPreviousFileSystemSettings(ctx context.Context, d *schema.ResourceData) error {
+	if d.Get("on_destroy").(string) != RestorePrevious.String() {
+		return nil
+	}
+
+	permissions, err := DoRequest[FileSystemPermissionsSettingsBody, FileSystemPermissionsSettingsBody](ctx,
+		c, GET, FileSystemPermissionsEndpoint, nil)
+	if err != nil {
+		return err
+	}
+	atimeSettings, err := DoRequest[FileSystemAtimeSettingsBody, FileSystemAtimeSettingsBody](ctx, c, GET,
+		FileSystemAtimeEndpoint, nil)
+	if err != nil {
+		return err
+	}
+
+	return setPreviousSettings(ctx, d, FileSystemSettingsBody{Permissions: permissions, AtimeSettings: atimeSettings})
+}
+
+func (c *Client) putFileSystemSettings(ctx context.Context, settings FileSystemSettingsBody) error {
+	if settings.Permissions != nil {
+		_, err := DoRequest[FileSystemPermissionsSettingsBody, FileSystemPermissionsSettingsBody](ctx,
+			c, PUT, FileSystemPermissionsEndpoint, settings.Permissions)
+		if err != nil {
+			return err
+		}
+	}
+
+	if settings.AtimeSettings != nil {
+		_, err := DoRequest[FileSystemAtimeSettingsBody, FileSystemAtimeSettingsBody](ctx, c, PUT,
+			FileSystemAtimeEndpoint, settings.AtimeSettings)
+		if err != nil {
+			return err
+		}
+	}
 
 	return nil
 }
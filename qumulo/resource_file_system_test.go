@@ -12,8 +12,8 @@ import (
 
 func TestAccCreateFileSystemSettings(t *testing.T) {
 	resource.Test(t, resource.TestCase{
-		PreCheck:  func() { testAccPreCheck(t) },
-		Providers: testAccProviders,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccFileSystemSettingsConfig(defaultFileSystemSettings),
@@ -44,6 +44,39 @@ func TestAccCreateFileSystemSettings(t *testing.T) {
 	})
 }
 
+func TestAccFileSystemSettingsRestorePreviousOnDestroy(t *testing.T) {
+	var previousSettings FileSystemSettingsBody
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: func(s *terraform.State) error {
+			return resource.ComposeTestCheckFunc(
+				testAccCheckFileSystemPermissionsSettings(*previousSettings.Permissions),
+				testAccCheckFileSystemAtimeSettings(*previousSettings.AtimeSettings),
+			)(s)
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "qumulo_file_system_settings" "test_fs_settings" {
+	permissions_mode = %q
+	atime_enabled = %v
+	atime_granularity = %q
+	on_destroy = %q
+}
+  `, testPermissionsSettings.Mode, testAtimeSettings.Enabled, testAtimeSettings.Granularity, RestorePrevious),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCompareFileSystemSettingsResource(testFileSystemSettings),
+					testAccCheckFileSystemPermissionsSettings(testPermissionsSettings),
+					testAccCheckFileSystemAtimeSettings(testAtimeSettings),
+					testAccReadPreviousSettings("qumulo_file_system_settings.test_fs_settings", &previousSettings),
+				),
+			},
+		},
+	})
+}
+
 var defaultPermissionsSettings = FileSystemPermissionsSettingsBody{
 	Mode: CrossProtocol.String(),
 }
@@ -2,7 +2,6 @@ package qumulo
 
 import (
 	"context"
-	"strconv"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
@@ -23,6 +22,18 @@ type FtpServerBody struct {
 	Greeting                    string                  `json:"greeting"`
 }
 
+// Settings applied on destroy when on_destroy = "reset_to_defaults"
+var FtpServerDefaults = FtpServerBody{
+	Enabled:                     true,
+	CheckRemoteHost:             true,
+	LogOperations:               true,
+	ChrootUsers:                 true,
+	AllowUnencryptedConnections: true,
+	ExpandWildcards:             false,
+	AnonymousUser:               nil,
+	Greeting:                    "Hello!",
+}
+
 func resourceFtpServer() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceFtpServerCreate,
@@ -73,19 +84,27 @@ func resourceFtpServer() *schema.Resource {
 				Type:     schema.TypeString,
 				Required: true,
 			},
-		},
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			"on_destroy":        onDestroySchema(Retain),
+			"previous_settings": previousSettingsSchema(),
+			"etag":              etagSchema(),
 		},
 	}
 }
 
 func resourceFtpServerCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+
+	if err := recordPreviousSettings[FtpServerBody](ctx, c, d, FtpServerEndpoint); err != nil {
+		return diag.FromErr(err)
+	}
+
 	err := modifyFtpServerSettings(ctx, d, m, PUT)
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	d.SetId(strconv.FormatInt(time.Now().Unix(), 10))
+	if err := setSingletonId(ctx, d, m); err != nil {
+		return diag.FromErr(err)
+	}
 
 	return resourceFtpServerRead(ctx, d, m)
 }
@@ -100,6 +119,8 @@ func resourceFtpServerRead(ctx context.Context, d *schema.ResourceData, m interf
 		return diag.FromErr(err)
 	}
 
+	errs.addMaybeError(readETag(d, m, FtpServerEndpoint))
+
 	errs.addMaybeError(d.Set("enabled", ftpServer.Enabled))
 	errs.addMaybeError(d.Set("check_remote_host", ftpServer.CheckRemoteHost))
 	errs.addMaybeError(d.Set("log_operations", ftpServer.LogOperations))
@@ -113,6 +134,8 @@ func resourceFtpServerRead(ctx context.Context, d *schema.ResourceData, m interf
 }
 
 func resourceFtpServerUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	expectETag(d, m, FtpServerEndpoint)
+
 	err := modifyFtpServerSettings(ctx, d, m, PATCH)
 	if err != nil {
 		return diag.FromErr(err)
@@ -123,7 +146,10 @@ func resourceFtpServerUpdate(ctx context.Context, d *schema.ResourceData, m inte
 
 func resourceFtpServerDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	tflog.Info(ctx, "Deleting FTP server resource")
-	return nil
+
+	c := m.(*Client)
+
+	return destroySingletonSettings(ctx, c, d, FtpServerEndpoint, &FtpServerDefaults)
 }
 
 func modifyFtpServerSettings(ctx context.Context, d *schema.ResourceData, m interface{}, method Method) error {
@@ -142,6 +168,10 @@ func modifyFtpServerSettings(ctx context.Context, d *schema.ResourceData, m inte
 		ftpServer.AnonymousUser = &v
 	}
 	tflog.Debug(ctx, "Modifying FTP server settings")
+	if method == PATCH {
+		return doMergePatch[FtpServerBody, FtpServerBody](ctx, c, d, FtpServerEndpoint, ftpServer, nil)
+	}
+
 	_, err := DoRequest[FtpServerBody, FtpServerBody](ctx, c, method, FtpServerEndpoint, &ftpServer)
 	return err
 }
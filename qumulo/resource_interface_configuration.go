@@ -59,6 +59,9 @@ func resourceInterfaceConfiguration() *schema.Resource {
 			Delete: schema.DefaultTimeout(1 * time.Minute),
 		},
 
+		SchemaVersion:  0,
+		StateUpgraders: []schema.StateUpgrader{},
+
 		Schema: map[string]*schema.Schema{
 			"id": &schema.Schema{
 				Type:     schema.TypeString,
@@ -69,12 +72,14 @@ func resourceInterfaceConfiguration() *schema.Resource {
 				Optional: true,
 			},
 			"default_gateway": &schema.Schema{
-				Type:     schema.TypeString,
-				Required: true,
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IsIPv4Address),
 			},
 			"default_gateway_ipv6": &schema.Schema{
-				Type:     schema.TypeString,
-				Optional: true,
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.Any(validation.StringIsEmpty, validation.IsIPv6Address)),
 			},
 			"bonding_mode": &schema.Schema{
 				Type:             schema.TypeString,
@@ -12,8 +12,8 @@ import (
 
 func TestAccInterfaceConfiguration(t *testing.T) {
 	resource.Test(t, resource.TestCase{
-		PreCheck:  func() { testAccPreCheck(t) },
-		Providers: testAccProviders,
+		PreCheck:                 func() { testAccPreCheckRealCluster(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccInterfaceConfiguration(defaultInterfaceConfiguration),
@@ -3,9 +3,9 @@ package qumulo
 import (
 	"context"
 	"fmt"
-	"strconv"
 	"time"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -26,6 +26,9 @@ func (e LdapSchema) String() string {
 
 const LdapServerEndpoint = "/v2/ldap/settings"
 
+// Connection health reported by the LDAP status endpoint once the cluster has bound successfully
+const LdapConnectionHealthy = "HEALTHY"
+
 type LdapServerSettingsBody struct {
 	UseLdap                bool                  `json:"use_ldap"`
 	BindUri                string                `json:"bind_uri"`
@@ -50,8 +53,15 @@ type LdapSchemaDescription struct {
 
 var LdapSchemaValues = []string{"RFC2307", "CUSTOM"}
 
+// Settings applied on destroy when on_destroy = "reset_to_defaults"
+var LdapServerDefaults = LdapServerSettingsBody{
+	UseLdap:           false,
+	LdapSchema:        Rfc2307.String(),
+	EncryptConnection: true,
+}
+
 func resourceLdapServer() *schema.Resource {
-	return &schema.Resource{
+	r := &schema.Resource{
 		CreateContext: resourceLdapServerCreate,
 		ReadContext:   resourceLdapServerRead,
 		UpdateContext: resourceLdapServerUpdate,
@@ -78,11 +88,9 @@ func resourceLdapServer() *schema.Resource {
 				Optional: true,
 				Default:  "",
 			},
-			"password": &schema.Schema{
-				Type:      schema.TypeString,
-				Optional:  true,
-				Sensitive: true,
-			},
+			"password":         secretSchema(),
+			"password_wo":      writeOnlySecretSchema("password"),
+			"password_version": secretVersionSchema(),
 			"base_distinguished_names": &schema.Schema{
 				Type:     schema.TypeString,
 				Required: true,
@@ -140,12 +148,29 @@ func resourceLdapServer() *schema.Resource {
 				Optional: true,
 				Default:  true,
 			},
+			// The bind password can't be read back, so restoring the previous settings would break binding
+			"on_destroy": onDestroySchema(Retain, Retain, ResetToDefaults),
+			"etag":       etagSchema(),
 		},
+	}
 
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+	// Version 0 states were identified by the Unix time at which they were created, and had no write-only
+	// password, on_destroy or etag. singletonResource leaves this upgrader in place.
+	r.SchemaVersion = 1
+	r.StateUpgraders = []schema.StateUpgrader{
+		{
+			Version: 0,
+			Type: earlierStateType(r, map[string]cty.Type{
+				"password_version": cty.NilType,
+				"password_wo":      cty.NilType,
+				"on_destroy":       cty.NilType,
+				"etag":             cty.NilType,
+			}),
+			Upgrade: upgradeWithDefaults(r, upgradeSingletonIdV0),
 		},
 	}
+
+	return r
 }
 
 func resourceLdapServerCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -153,7 +178,14 @@ func resourceLdapServerCreate(ctx context.Context, d *schema.ResourceData, m int
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	d.SetId(strconv.FormatInt(time.Now().Unix(), 10))
+
+	if err := setSecretHash(d, "password", getSecret(d, "password")); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := setSingletonId(ctx, d, m); err != nil {
+		return diag.FromErr(err)
+	}
 
 	return resourceLdapServerRead(ctx, d, m)
 }
@@ -167,10 +199,12 @@ func resourceLdapServerRead(ctx context.Context, d *schema.ResourceData, m inter
 	if err != nil {
 		return diag.FromErr(err)
 	}
+
+	errs.addMaybeError(readETag(d, m, LdapServerEndpoint))
 	errs.addMaybeError(d.Set("use_ldap", ls.UseLdap))
 	errs.addMaybeError(d.Set("bind_uri", ls.BindUri))
 	errs.addMaybeError(d.Set("user", ls.User))
-	errs.addMaybeError(d.Set("password", ls.Password))
+	errs.addMaybeError(setSecretHash(d, "password", d.Get("password").(string)))
 	errs.addMaybeError(d.Set("base_distinguished_names", ls.BaseDistinguishedNames))
 	errs.addMaybeError(d.Set("ldap_schema", ls.LdapSchema))
 	errs.addMaybeError(d.Set("encrypt_connection", ls.EncryptConnection))
@@ -180,22 +214,41 @@ func resourceLdapServerRead(ctx context.Context, d *schema.ResourceData, m inter
 	if err != nil {
 		return diag.FromErr(fmt.Errorf("error setting LDAP schema description: %w", err))
 	}
+
+	if ls.UseLdap && ls.User != "" {
+		diags, err := verifyLdapBind(ctx, c)
+		if err != nil {
+			return append(errs.diags, diag.FromErr(err)...)
+		}
+		errs.diags = append(errs.diags, diags...)
+	}
+
 	return errs.diags
 }
 
 func resourceLdapServerUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	expectETag(d, m, LdapServerEndpoint)
+
 	err := setLdapServerSettings(ctx, d, m, PATCH)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
+	if d.HasChange("password") {
+		if err := setSecretHash(d, "password", getSecret(d, "password")); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	return resourceLdapServerRead(ctx, d, m)
 }
 
 func resourceLdapServerDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	tflog.Info(ctx, "Deleting LDAP settings resource")
 
-	return nil
+	c := m.(*Client)
+
+	return destroySingletonSettings(ctx, c, d, LdapServerEndpoint, &LdapServerDefaults)
 }
 
 func setLdapServerSettings(ctx context.Context, d *schema.ResourceData, m interface{}, method Method) error {
@@ -214,15 +267,48 @@ func setLdapServerSettings(ctx context.Context, d *schema.ResourceData, m interf
 		ldapServerSettings.User = v
 	}
 
-	if v := d.Get("password").(string); v != "" {
+	// Only a hash of the password is kept in state, so it is taken from the config
+	if v := getSecret(d, "password"); v != "" {
+		ldapServerSettings.Password = v
+	} else if v := getSecret(d, "password_wo"); v != "" {
 		ldapServerSettings.Password = v
 	}
 
 	tflog.Debug(ctx, "Updating LDAP settings")
+	if method == PATCH {
+		return doMergePatch[LdapServerSettingsBody, LdapServerSettingsBody](ctx, c, d, LdapServerEndpoint, ldapServerSettings,
+			map[string][]string{"password": {"password", "password_wo", "password_version"}})
+	}
+
 	_, err := DoRequest[LdapServerSettingsBody, LdapServerSettingsBody](ctx, c, method, LdapServerEndpoint, &ldapServerSettings)
 	return err
 }
 
+// The cluster reports per node whether it could bind with the configured credentials. A failed bind
+// usually means the password was changed on the directory server, which can't be seen through the
+// settings endpoint.
+func verifyLdapBind(ctx context.Context, c *Client) (diag.Diagnostics, error) {
+	ldapStatus, err := DoRequest[LdapStatusResponse, LdapStatusResponse](ctx, c, GET, LdapStatusEndpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var diags diag.Diagnostics
+	for _, state := range ldapStatus.LdapConnectionStates {
+		if state.Health == LdapConnectionHealthy {
+			continue
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "LDAP bind is not healthy",
+			Detail: fmt.Sprintf("Node %d reports LDAP connection health %q for bind account %q; the bind credentials may no longer be valid",
+				state.NodeId, state.Health, state.BindAccount),
+		})
+	}
+
+	return diags, nil
+}
+
 func expandLdapSchemaDescription(tfLdapSchemaDescriptions []interface{}) LdapSchemaDescription {
 	apiObject := LdapSchemaDescription{}
 
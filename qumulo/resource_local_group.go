@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -34,7 +35,7 @@ type GroupResponse struct {
 type DeleteGroupBody struct{}
 
 func resourceGroup() *schema.Resource {
-	return &schema.Resource{
+	r := &schema.Resource{
 		CreateContext: resourceGroupCreate,
 		ReadContext:   resourceGroupRead,
 		UpdateContext: resourceGroupUpdate,
@@ -56,7 +57,7 @@ func resourceGroup() *schema.Resource {
 				Computed: true,
 			},
 			"gid": &schema.Schema{
-				Type:     schema.TypeString,
+				Type:     schema.TypeInt,
 				Optional: true,
 			},
 		},
@@ -65,6 +66,25 @@ func resourceGroup() *schema.Resource {
 			StateContext: schema.ImportStatePassthroughContext,
 		},
 	}
+
+	// The GID was a string in version 0
+	r.SchemaVersion = 1
+	r.StateUpgraders = []schema.StateUpgrader{
+		{
+			Version: 0,
+			Type:    earlierStateType(r, map[string]cty.Type{"gid": cty.String}),
+			Upgrade: upgradeWithDefaults(r, upgradeGroupV0),
+		},
+	}
+
+	return r
+}
+
+func upgradeGroupV0(ctx context.Context, rawState map[string]interface{}, m interface{}) (map[string]interface{}, error) {
+	if err := upgradeStringToInt(rawState, "gid"); err != nil {
+		return nil, err
+	}
+	return rawState, nil
 }
 
 func resourceGroupCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -72,7 +92,7 @@ func resourceGroupCreate(ctx context.Context, d *schema.ResourceData, m interfac
 
 	groupSettings := CreateGroupRequest{
 		Name: d.Get("name").(string),
-		Gid:  d.Get("gid").(string),
+		Gid:  GetIntAsString(d, "gid"),
 	}
 
 	tflog.Debug(ctx, fmt.Sprintf("Creating local group with name %q", groupSettings.Name))
@@ -101,7 +121,7 @@ func resourceGroupRead(ctx context.Context, d *schema.ResourceData, m interface{
 
 	errs.addMaybeError(d.Set("name", group.Name))
 	errs.addMaybeError(d.Set("sid", group.Sid))
-	errs.addMaybeError(d.Set("gid", group.Gid))
+	errs.addMaybeError(SetIntFromString(d, "gid", group.Gid))
 
 	return errs.diags
 }
@@ -112,7 +132,7 @@ func resourceGroupUpdate(ctx context.Context, d *schema.ResourceData, m interfac
 	groupSettings := UpdateGroupRequest{
 		Id:   d.Id(),
 		Name: d.Get("name").(string),
-		Gid:  d.Get("gid").(string),
+		Gid:  GetIntAsString(d, "gid"),
 	}
 
 	updateGroupByIdUri := GroupsEndpoint + d.Id()
@@ -30,6 +30,9 @@ func resourceGroupMember() *schema.Resource {
 			Delete: schema.DefaultTimeout(1 * time.Minute),
 		},
 
+		SchemaVersion:  0,
+		StateUpgraders: []schema.StateUpgrader{},
+
 		Schema: map[string]*schema.Schema{
 			"member_id": &schema.Schema{
 				Type:     schema.TypeString,
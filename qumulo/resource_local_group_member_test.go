@@ -10,8 +10,8 @@ import (
 func TestAccAddGroupMember(t *testing.T) {
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:  func() { testAccPreCheck(t) },
-		Providers: testAccProviders,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccMemberConfig(group1, user1),
@@ -22,13 +22,13 @@ func TestAccAddGroupMember(t *testing.T) {
 }
 
 var user1 = UserBody{
-	Name:         "test_user4",
+	Name:         TestNamePrefix + "_user4",
 	PrimaryGroup: "514",
 	Password:     "Test1234",
 }
 
 var group1 = CreateGroupRequest{
-	Name: "test_group4",
+	Name: TestNamePrefix + "_group4",
 	Gid:  "",
 }
 
@@ -36,13 +36,13 @@ func testAccMemberConfig(group CreateGroupRequest, user UserBody) string {
 	return fmt.Sprintf(`
 resource "qumulo_local_group" "test_group" {
 	name = %q
-	gid = %q
+	gid = %v
 }
 
 resource "qumulo_local_user" "test_user" {
 	name = %q
 	primary_group = %v
-	uid = "%v"
+	uid = %v
 	home_directory = %q
 	password = %q
 }
@@ -51,7 +51,7 @@ resource "qumulo_local_group_member" "test_member" {
 	member_id = qumulo_local_user.test_user.id
 	group_id = qumulo_local_group.test_group.id
 }
-  `, group.Name, group.Gid, user.Name, user.PrimaryGroup, user.Uid, user.HomeDirectory, user.Password)
+  `, group.Name, PrintTerraformNumberFromString(group.Gid), user.Name, user.PrimaryGroup, PrintTerraformNumberFromString(user.Uid), user.HomeDirectory, user.Password)
 }
 
 func testAccValidateGroupMember() resource.TestCheckFunc {
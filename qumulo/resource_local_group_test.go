@@ -12,8 +12,8 @@ import (
 func TestAccCreateGroup(t *testing.T) {
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:  func() { testAccPreCheck(t) },
-		Providers: testAccProviders,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccGroupConfig(groupTest1),
@@ -34,12 +34,12 @@ func TestAccCreateGroup(t *testing.T) {
 }
 
 var groupTest1 = CreateGroupRequest{
-	Name: "test_group",
+	Name: TestNamePrefix + "_group",
 	Gid:  "",
 }
 
 var groupTest2 = CreateGroupRequest{
-	Name: "test_group2",
+	Name: TestNamePrefix + "_group2",
 	Gid:  "",
 }
 
@@ -47,9 +47,9 @@ func testAccGroupConfig(group CreateGroupRequest) string {
 	return fmt.Sprintf(`
 resource "qumulo_local_group" "test_group" {
 	name = %q
-	gid = %q
+	gid = %v
 }
-  `, group.Name, group.Gid)
+  `, group.Name, PrintTerraformNumberFromString(group.Gid))
 }
 
 func testAccCompareGroupResource(group CreateGroupRequest) resource.TestCheckFunc {
@@ -57,7 +57,7 @@ func testAccCompareGroupResource(group CreateGroupRequest) resource.TestCheckFun
 		resource.TestCheckResourceAttr("qumulo_local_group.test_group", "name",
 			group.Name),
 		resource.TestCheckResourceAttr("qumulo_local_group.test_group", "gid",
-			group.Gid),
+			testAccStateNumber(group.Gid)),
 	)
 }
 
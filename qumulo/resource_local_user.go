@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -33,7 +34,7 @@ type UserModify struct {
 }
 
 func resourceUser() *schema.Resource {
-	return &schema.Resource{
+	r := &schema.Resource{
 		CreateContext: resourceUserCreate,
 		ReadContext:   resourceUserRead,
 		UpdateContext: resourceUserUpdate,
@@ -59,18 +60,16 @@ func resourceUser() *schema.Resource {
 				Computed: true,
 			},
 			"uid": &schema.Schema{
-				Type:     schema.TypeString,
+				Type:     schema.TypeInt,
 				Optional: true,
 			},
 			"home_directory": &schema.Schema{
 				Type:     schema.TypeString,
 				Optional: true,
 			},
-			"password": &schema.Schema{
-				Type:      schema.TypeString,
-				Optional:  true,
-				Sensitive: true,
-			},
+			"password":         secretSchema(),
+			"password_wo":      writeOnlySecretSchema("password"),
+			"password_version": secretVersionSchema(),
 			"can_change_password": &schema.Schema{
 				Type:     schema.TypeBool,
 				Computed: true,
@@ -81,13 +80,36 @@ func resourceUser() *schema.Resource {
 			StateContext: schema.ImportStatePassthroughContext,
 		},
 	}
+
+	// The UID was a string in version 0
+	r.SchemaVersion = 1
+	r.StateUpgraders = []schema.StateUpgrader{
+		{
+			Version: 0,
+			Type: earlierStateType(r, map[string]cty.Type{
+				"uid":              cty.String,
+				"password_version": cty.NilType,
+				"password_wo":      cty.NilType,
+			}),
+			Upgrade: upgradeWithDefaults(r, upgradeUserV0),
+		},
+	}
+
+	return r
+}
+
+func upgradeUserV0(ctx context.Context, rawState map[string]interface{}, m interface{}) (map[string]interface{}, error) {
+	if err := upgradeStringToInt(rawState, "uid"); err != nil {
+		return nil, err
+	}
+	return rawState, nil
 }
 
 func resourceUserCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*Client)
 
-	if d.Get("password") == "" {
-		return diag.FromErr(fmt.Errorf("password must be set when creating user"))
+	if localUserPassword(d) == "" {
+		return diag.FromErr(fmt.Errorf("password or password_wo must be set when creating user"))
 	}
 
 	userSettings := setUserSettings(ctx, d, m)
@@ -101,6 +123,10 @@ func resourceUserCreate(ctx context.Context, d *schema.ResourceData, m interface
 
 	d.SetId(user.Id)
 
+	if err := setSecretHash(d, "password", getSecret(d, "password")); err != nil {
+		return diag.FromErr(err)
+	}
+
 	return resourceUserRead(ctx, d, m)
 
 }
@@ -120,9 +146,10 @@ func resourceUserRead(ctx context.Context, d *schema.ResourceData, m interface{}
 	errs.addMaybeError(d.Set("name", user.Name))
 	errs.addMaybeError(d.Set("primary_group", user.PrimaryGroup))
 	errs.addMaybeError(d.Set("sid", user.Sid))
-	errs.addMaybeError(d.Set("uid", user.Uid))
+	errs.addMaybeError(SetIntFromString(d, "uid", user.Uid))
 	errs.addMaybeError(d.Set("home_directory", user.HomeDirectory))
 	errs.addMaybeError(d.Set("can_change_password", user.CanChangePassword))
+	errs.addMaybeError(setSecretHash(d, "password", d.Get("password").(string)))
 
 	return errs.diags
 }
@@ -136,7 +163,8 @@ func resourceUserUpdate(ctx context.Context, d *schema.ResourceData, m interface
 
 	var err error
 
-	if d.Get("password") != "" {
+	// The password is only sent when it was changed or rotated through password_version
+	if d.HasChanges("password", "password_version") && localUserPassword(d) != "" {
 		userSettings := setUserSettings(ctx, d, m)
 		userSettings.Id = d.Id()
 		_, err = DoRequest[UserBody, UserBody](ctx, c, PUT, updateUserByNameUri, &userSettings)
@@ -150,6 +178,12 @@ func resourceUserUpdate(ctx context.Context, d *schema.ResourceData, m interface
 		return diag.FromErr(err)
 	}
 
+	if d.HasChange("password") {
+		if err := setSecretHash(d, "password", getSecret(d, "password")); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	return resourceUserRead(ctx, d, m)
 }
 
@@ -174,20 +208,29 @@ func setUserSettings(ctx context.Context, d *schema.ResourceData, m interface{})
 	userConfig := UserBody{
 		Name:          d.Get("name").(string),
 		PrimaryGroup:  d.Get("primary_group").(string),
-		Uid:           d.Get("uid").(string),
+		Uid:           GetIntAsString(d, "uid"),
 		HomeDirectory: d.Get("home_directory").(string),
-		Password:      d.Get("password").(string),
+		Password:      localUserPassword(d),
 	}
 
 	return userConfig
 }
 
+// The password may come from either the hashed or the write-only argument
+func localUserPassword(d *schema.ResourceData) string {
+	if v := getSecret(d, "password"); v != "" {
+		return v
+	}
+
+	return getSecret(d, "password_wo")
+}
+
 func modifyUserSettings(ctx context.Context, d *schema.ResourceData, m interface{}) UserModify {
 
 	userConfig := UserModify{
 		Name:          d.Get("name").(string),
 		PrimaryGroup:  d.Get("primary_group").(string),
-		Uid:           d.Get("uid").(string),
+		Uid:           GetIntAsString(d, "uid"),
 		HomeDirectory: d.Get("home_directory").(string),
 	}
 
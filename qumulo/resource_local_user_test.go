@@ -12,8 +12,8 @@ import (
 func TestAccCreateUser(t *testing.T) {
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:  func() { testAccPreCheck(t) },
-		Providers: testAccProviders,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccUserConfig(userTest1),
@@ -34,13 +34,13 @@ func TestAccCreateUser(t *testing.T) {
 }
 
 var userTest1 = UserBody{
-	Name:         "test_user1",
+	Name:         TestNamePrefix + "_user1",
 	PrimaryGroup: "514",
 	Password:     "Test1234",
 }
 
 var userTest2 = UserBody{
-	Name:          "test_user2",
+	Name:          TestNamePrefix + "_user2",
 	PrimaryGroup:  "513",
 	Uid:           "123",
 	HomeDirectory: "/",
@@ -52,11 +52,11 @@ func testAccUserConfig(user UserBody) string {
 resource "qumulo_local_user" "test_user" {
 	name = %q
 	primary_group = %v
-	uid = "%v"
+	uid = %v
 	home_directory = %q
 	password = %q
 }
-  `, user.Name, user.PrimaryGroup, user.Uid, user.HomeDirectory, user.Password)
+  `, user.Name, user.PrimaryGroup, PrintTerraformNumberFromString(user.Uid), user.HomeDirectory, user.Password)
 }
 
 func testAccCompareUserResource(user UserBody) resource.TestCheckFunc {
@@ -66,11 +66,11 @@ func testAccCompareUserResource(user UserBody) resource.TestCheckFunc {
 		resource.TestCheckResourceAttr("qumulo_local_user.test_user", "primary_group",
 			user.PrimaryGroup),
 		resource.TestCheckResourceAttr("qumulo_local_user.test_user", "uid",
-			user.Uid),
+			testAccStateNumber(user.Uid)),
 		resource.TestCheckResourceAttr("qumulo_local_user.test_user", "home_directory",
 			user.HomeDirectory),
-		resource.TestCheckResourceAttr("qumulo_local_user.test_user", "password",
-			user.Password),
+		resource.TestCheckResourceAttrWith("qumulo_local_user.test_user", "password",
+			testAccCheckSecretHash(user.Password)),
 	)
 }
 
@@ -2,13 +2,13 @@ package qumulo
 
 import (
 	"context"
-	"strconv"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 const MonitoringEndpoint = "/v1/support/settings"
@@ -31,6 +31,21 @@ type MonitoringResponse struct {
 	MonitorUri string `json:"monitor_uri"`
 }
 
+// Settings applied on destroy when on_destroy = "reset_to_defaults"
+var MonitoringDefaults = MonitoringSettings{
+	Enabled:             false,
+	MqHost:              "missionq.qumulo.com",
+	MqPort:              443,
+	MqProxyHost:         "",
+	MqProxyPort:         0,
+	S3ProxyHost:         "monitor.qumulo.com",
+	S3ProxyPort:         443,
+	S3ProxyDisableHttps: false,
+	VpnEnabled:          false,
+	VpnHost:             "ep1.qumulo.com",
+	Period:              60,
+}
+
 func resourceMonitoring() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceMonitoringCreate,
@@ -50,24 +65,27 @@ func resourceMonitoring() *schema.Resource {
 				Optional: true,
 			},
 			"mq_host": &schema.Schema{
-				Type:     schema.TypeString,
-				Required: true,
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.Any(validation.StringIsEmpty, validateHostOrIpAddress)),
 			},
 			"mq_port": &schema.Schema{
 				Type:     schema.TypeInt,
 				Required: true,
 			},
 			"mq_proxy_host": &schema.Schema{
-				Type:     schema.TypeString,
-				Required: true,
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.Any(validation.StringIsEmpty, validateHostOrIpAddress)),
 			},
 			"mq_proxy_port": &schema.Schema{
 				Type:     schema.TypeInt,
 				Required: true,
 			},
 			"s3_proxy_host": &schema.Schema{
-				Type:     schema.TypeString,
-				Required: true,
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.Any(validation.StringIsEmpty, validateHostOrIpAddress)),
 			},
 			"s3_proxy_port": &schema.Schema{
 				Type:     schema.TypeInt,
@@ -82,27 +100,35 @@ func resourceMonitoring() *schema.Resource {
 				Optional: true,
 			},
 			"vpn_host": &schema.Schema{
-				Type:     schema.TypeString,
-				Required: true,
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.Any(validation.StringIsEmpty, validateHostOrIpAddress)),
 			},
 			"period": &schema.Schema{
 				Type:     schema.TypeInt,
 				Required: true,
 			},
-		},
-
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			"on_destroy":        onDestroySchema(Retain),
+			"previous_settings": previousSettingsSchema(),
+			"etag":              etagSchema(),
 		},
 	}
 }
 
 func resourceMonitoringCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+
+	if err := recordPreviousSettings[MonitoringSettings](ctx, c, d, MonitoringEndpoint); err != nil {
+		return diag.FromErr(err)
+	}
+
 	err := setMonitoringSettings(ctx, d, m, PUT)
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	d.SetId(strconv.FormatInt(time.Now().Unix(), 10))
+	if err := setSingletonId(ctx, d, m); err != nil {
+		return diag.FromErr(err)
+	}
 
 	return resourceMonitoringRead(ctx, d, m)
 }
@@ -116,6 +142,8 @@ func resourceMonitoringRead(ctx context.Context, d *schema.ResourceData, m inter
 	if err != nil {
 		return diag.FromErr(err)
 	}
+
+	errs.addMaybeError(readETag(d, m, MonitoringEndpoint))
 	errs.addMaybeError(d.Set("enabled", settings.Enabled))
 	errs.addMaybeError(d.Set("mq_host", settings.MqHost))
 	errs.addMaybeError(d.Set("mq_port", settings.MqPort))
@@ -132,6 +160,8 @@ func resourceMonitoringRead(ctx context.Context, d *schema.ResourceData, m inter
 }
 
 func resourceMonitoringUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	expectETag(d, m, MonitoringEndpoint)
+
 	err := setMonitoringSettings(ctx, d, m, PATCH)
 	if err != nil {
 		return diag.FromErr(err)
@@ -142,7 +172,9 @@ func resourceMonitoringUpdate(ctx context.Context, d *schema.ResourceData, m int
 func resourceMonitoringDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	tflog.Info(ctx, "Deleting monitor settings resource")
 
-	return nil
+	c := m.(*Client)
+
+	return destroySingletonSettings(ctx, c, d, MonitoringEndpoint, &MonitoringDefaults)
 }
 
 func setMonitoringSettings(ctx context.Context, d *schema.ResourceData, m interface{}, method Method) error {
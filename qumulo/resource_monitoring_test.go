@@ -11,8 +11,8 @@ import (
 
 func TestAccChangeMonitoring(t *testing.T) {
 	resource.Test(t, resource.TestCase{
-		PreCheck:  func() { testAccPreCheck(t) },
-		Providers: testAccProviders,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{ // Reset state to default
 				Config: testAccMonitoringConf(defaultMonitoringConfig),
@@ -59,6 +59,9 @@ func resourceNetworkConfiguration() *schema.Resource {
 			Delete: schema.DefaultTimeout(1 * time.Minute),
 		},
 
+		SchemaVersion:  0,
+		StateUpgraders: []schema.StateUpgrader{},
+
 		Schema: map[string]*schema.Schema{
 			"id": &schema.Schema{
 				Type:     schema.TypeString,
@@ -73,20 +76,8 @@ func resourceNetworkConfiguration() *schema.Resource {
 				Required:         true,
 				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(assignedByValues, false)),
 			},
-			"floating_ip_ranges": &schema.Schema{
-				Type:     schema.TypeList,
-				Optional: true,
-				Elem: &schema.Schema{
-					Type: schema.TypeString,
-				},
-			},
-			"dns_servers": &schema.Schema{
-				Type:     schema.TypeList,
-				Optional: true,
-				Elem: &schema.Schema{
-					Type: schema.TypeString,
-				},
-			},
+			"floating_ip_ranges": ipRangeListSchema(),
+			"dns_servers":        ipAddressListSchema(),
 			"dns_search_domains": &schema.Schema{
 				Type:     schema.TypeList,
 				Optional: true,
@@ -94,17 +85,12 @@ func resourceNetworkConfiguration() *schema.Resource {
 					Type: schema.TypeString,
 				},
 			},
-			"ip_ranges": &schema.Schema{
-				Type:     schema.TypeList,
-				Optional: true,
-				Elem: &schema.Schema{
-					Type: schema.TypeString,
-				},
-			},
+			"ip_ranges": ipRangeListSchema(),
 			"netmask": &schema.Schema{
-				Type:     schema.TypeString,
-				Optional: true,
-				Default:  "",
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "",
+				ValidateDiagFunc: validation.ToDiagFunc(validation.Any(validation.StringIsEmpty, validateNetmask)),
 			},
 			"mtu": &schema.Schema{
 				Type:     schema.TypeInt,
@@ -124,6 +110,7 @@ func resourceNetworkConfiguration() *schema.Resource {
 				ForceNew: true,
 			},
 		},
+		CustomizeDiff: resourceNetworkConfigurationCustomizeDiff,
 		Importer: &schema.ResourceImporter{
 			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 				// d.Id() here is the last argument passed to the
@@ -141,6 +128,22 @@ func resourceNetworkConfiguration() *schema.Resource {
 	}
 }
 
+// The cluster only rejects a floating IP which is also assigned statically once the change is applied
+func resourceNetworkConfigurationCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.NewValueKnown("floating_ip_ranges") || !d.NewValueKnown("ip_ranges") {
+		return nil
+	}
+
+	ranges := InterfaceSliceToStringSlice(d.Get("ip_ranges").([]interface{}))
+	ranges = append(ranges, InterfaceSliceToStringSlice(d.Get("floating_ip_ranges").([]interface{}))...)
+
+	if first, second, ok := findOverlappingIpRanges(ranges); ok {
+		return fmt.Errorf("IP ranges %q and %q overlap; static and floating IP ranges must not share addresses", first, second)
+	}
+
+	return nil
+}
+
 func resourceNetworkConfigurationCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	interfaceId := d.Get("interface_id").(string)
 	addNetworkConfigUri := InterfaceConfigurationEndpoint + interfaceId + NetworksEndpointSuffix
@@ -12,8 +12,8 @@ import (
 
 func TestAccCreateNfsExport(t *testing.T) {
 	resource.Test(t, resource.TestCase{
-		PreCheck:  func() { testAccPreCheck(t) },
-		Providers: testAccProviders,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: defaultAccNfsExport(defaultNfsExport),
@@ -31,7 +31,7 @@ func TestAccCreateNfsExport(t *testing.T) {
 }
 
 var defaultNfsExport = NfsExport{
-	ExportPath: "/my_very_own_export",
+	ExportPath: "/" + TestNamePrefix + "_export",
 	FsPath:     "/home/testing/my_very_own_export",
 	Restrictions: []NfsRestriction{{
 		HostRestrictions:      []string{},
@@ -43,7 +43,7 @@ var defaultNfsExport = NfsExport{
 }
 
 var testNfsExport = NfsExport{
-	ExportPath: "/my_very_own_export",
+	ExportPath: "/" + TestNamePrefix + "_export",
 	FsPath:     "/home/testing/my_very_own_export",
 	Restrictions: []NfsRestriction{{
 		HostRestrictions:      []string{"10.100.38.31"},
@@ -2,7 +2,6 @@ package qumulo
 
 import (
 	"context"
-	"strconv"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
@@ -18,6 +17,13 @@ type NfsSettingsBody struct {
 	AuthSysEnabled bool `json:"auth_sys_enabled"`
 }
 
+// Settings applied on destroy when on_destroy = "reset_to_defaults"
+var NfsSettingsDefaults = NfsSettingsBody{
+	V4Enabled:      true,
+	Krb5Enabled:    true,
+	AuthSysEnabled: true,
+}
+
 func resourceNfsSettings() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceNfsSettingsCreate,
@@ -47,20 +53,27 @@ func resourceNfsSettings() *schema.Resource {
 				Optional: true,
 				Default:  true,
 			},
-		},
-
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			"on_destroy":        onDestroySchema(Retain),
+			"previous_settings": previousSettingsSchema(),
+			"etag":              etagSchema(),
 		},
 	}
 }
 
 func resourceNfsSettingsCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+
+	if err := recordPreviousSettings[NfsSettingsBody](ctx, c, d, NfsSettingsEndpoint); err != nil {
+		return diag.FromErr(err)
+	}
+
 	err := setNfsSettings(ctx, d, m, PUT)
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	d.SetId(strconv.FormatInt(time.Now().Unix(), 10))
+	if err := setSingletonId(ctx, d, m); err != nil {
+		return diag.FromErr(err)
+	}
 	return resourceNfsSettingsRead(ctx, d, m)
 }
 
@@ -72,6 +85,8 @@ func resourceNfsSettingsRead(ctx context.Context, d *schema.ResourceData, m inte
 	if err != nil {
 		return diag.FromErr(err)
 	}
+
+	errs.addMaybeError(readETag(d, m, NfsSettingsEndpoint))
 	errs.addMaybeError(d.Set("v4_enabled", s.V4Enabled))
 	errs.addMaybeError(d.Set("krb5_enabled", s.Krb5Enabled))
 	errs.addMaybeError(d.Set("auth_sys_enabled", s.AuthSysEnabled))
@@ -80,18 +95,24 @@ func resourceNfsSettingsRead(ctx context.Context, d *schema.ResourceData, m inte
 }
 
 func resourceNfsSettingsUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	expectETag(d, m, NfsSettingsEndpoint)
+
 	err := setNfsSettings(ctx, d, m, PATCH)
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	d.SetId(strconv.FormatInt(time.Now().Unix(), 10))
+	if err := setSingletonId(ctx, d, m); err != nil {
+		return diag.FromErr(err)
+	}
 	return resourceNfsSettingsRead(ctx, d, m)
 }
 
 func resourceNfsSettingsDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	tflog.Info(ctx, "Deleting NFS settings resource")
 
-	return nil
+	c := m.(*Client)
+
+	return destroySingletonSettings(ctx, c, d, NfsSettingsEndpoint, &NfsSettingsDefaults)
 }
 
 func setNfsSettings(ctx context.Context, d *schema.ResourceData, m interface{}, method Method) error {
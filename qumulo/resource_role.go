@@ -13,12 +13,6 @@ import (
 
 const RolesEndpoint = "/v1/auth/roles/"
 
-type Role struct {
-	Name        string   `json:"name"`
-	Description string   `json:"description"`
-	Privileges  []string `json:"privileges"`
-}
-
 func resourceRole() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceRoleCreate,
@@ -32,24 +26,10 @@ func resourceRole() *schema.Resource {
 			Delete: schema.DefaultTimeout(1 * time.Minute),
 		},
 
-		Schema: map[string]*schema.Schema{
-			"name": &schema.Schema{
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
-			},
-			"description": &schema.Schema{
-				Type:     schema.TypeString,
-				Required: true,
-			},
-			"privileges": &schema.Schema{
-				Type:     schema.TypeList,
-				Required: true,
-				Elem: &schema.Schema{
-					Type: schema.TypeString,
-				},
-			},
-		},
+		SchemaVersion:  0,
+		StateUpgraders: []schema.StateUpgrader{},
+
+		Schema: roleSchema(),
 
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
@@ -74,19 +54,14 @@ func resourceRoleCreate(ctx context.Context, d *schema.ResourceData, m interface
 func resourceRoleRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*Client)
 
-	var errs ErrorCollection
-
 	readRoleByNameUri := RolesEndpoint + d.Id()
 
 	role, err := DoRequest[Role, Role](ctx, c, GET, readRoleByNameUri, nil)
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	errs.addMaybeError(d.Set("name", d.Id()))
-	errs.addMaybeError(d.Set("description", role.Description))
-	errs.addMaybeError(d.Set("privileges", role.Privileges))
 
-	return errs.diags
+	return flattenRole(d, role)
 }
 
 func resourceRoleUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -119,17 +94,7 @@ func resourceRoleDelete(ctx context.Context, d *schema.ResourceData, m interface
 }
 
 func setRoleSettings(ctx context.Context, d *schema.ResourceData, m interface{}) Role {
-	var privileges = []string{}
-
-	if v, ok := d.Get("privileges").([]interface{}); ok {
-		privileges = InterfaceSliceToStringSlice(v)
-	}
-
-	roleConfig := Role{
-		Name:        d.Get("name").(string),
-		Description: d.Get("description").(string),
-		Privileges:  privileges,
-	}
+	roleConfig := expandRole(d)
 
 	tflog.Debug(ctx, "Updating or creating Role: %v", map[string]interface{}{
 		"Name": roleConfig.Name,
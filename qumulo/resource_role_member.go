@@ -5,11 +5,16 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
-
-	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 // URL endpoints defined in separate files (RolesEndpoint and MembersSuffix)
@@ -37,93 +42,110 @@ type RoleMemberResponse struct {
 
 type RoleMemberDeleteBody struct{}
 
-func resourceRoleMember() *schema.Resource {
-	return &schema.Resource{
-		CreateContext: resourceRoleMemberCreate,
-		ReadContext:   resourceRoleMemberRead,
-		DeleteContext: resourceRoleMemberDelete,
+type roleMemberResource struct {
+	client *Client
+}
 
-		Timeouts: &schema.ResourceTimeout{
-			Create: schema.DefaultTimeout(1 * time.Minute),
-			Update: schema.DefaultTimeout(1 * time.Minute),
-			Delete: schema.DefaultTimeout(1 * time.Minute),
-		},
+var _ resource.ResourceWithConfigure = &roleMemberResource{}
+var _ resource.ResourceWithImportState = &roleMemberResource{}
+
+type roleMemberModel struct {
+	Id       types.String   `tfsdk:"id"`
+	Domain   types.String   `tfsdk:"domain"`
+	AuthId   types.String   `tfsdk:"auth_id"`
+	Uid      types.String   `tfsdk:"uid"`
+	Gid      types.String   `tfsdk:"gid"`
+	Sid      types.String   `tfsdk:"sid"`
+	Name     types.String   `tfsdk:"name"`
+	RoleName types.String   `tfsdk:"role_name"`
+	Timeouts timeouts.Value `tfsdk:"timeouts"`
+}
 
-		Schema: map[string]*schema.Schema{
-			"domain": &schema.Schema{
-				Type:             schema.TypeString,
-				Optional:         true,
-				Computed:         true,
-				ForceNew:         true,
-				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(RoleDomainValues, false)),
-			},
-			"auth_id": &schema.Schema{
-				Type:     schema.TypeString,
-				Optional: true,
-				Computed: true,
-				ForceNew: true,
-			},
-			"uid": &schema.Schema{
-				Type:     schema.TypeString,
-				Optional: true,
-				Computed: true,
-				ForceNew: true,
-			},
-			"gid": &schema.Schema{
-				Type:     schema.TypeString,
-				Optional: true,
-				Computed: true,
-				ForceNew: true,
-			},
-			"sid": &schema.Schema{
-				Type:     schema.TypeString,
-				Optional: true,
-				Computed: true,
-				ForceNew: true,
+func newRoleMemberResource() resource.Resource {
+	return &roleMemberResource{}
+}
+
+func (r *roleMemberResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_role_member"
+}
+
+// The member can be given by any of its identities, and the others are read back from the cluster. The
+// schema is the one the resource had in terraform-plugin-sdk, so that existing states can be read.
+func (r *roleMemberResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	identity := func(validators ...validator.String) schema.StringAttribute {
+		return schema.StringAttribute{
+			Optional: true,
+			Computed: true,
+			PlanModifiers: []planmodifier.String{
+				stringplanmodifier.RequiresReplaceIfConfigured(),
 			},
-			"name": &schema.Schema{
-				Type:     schema.TypeString,
-				Optional: true,
+			Validators: validators,
+		}
+	}
+
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
 				Computed: true,
-				ForceNew: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
-			"role_name": &schema.Schema{
-				Type:     schema.TypeString,
+			"domain":  identity(stringvalidator.OneOf(RoleDomainValues...)),
+			"auth_id": identity(),
+			"uid":     identity(),
+			"gid":     identity(),
+			"sid":     identity(),
+			"name":    identity(),
+			"role_name": schema.StringAttribute{
 				Required: true,
-				ForceNew: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 		},
-
-		Importer: &schema.ResourceImporter{
-			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-				// d.Id() here is the last argument passed to the
-				// `terraform import RESOURCE_TYPE.RESOURCE_NAME RESOURCE_ID` command
-				ids, err := ParseRoleMemberId(d.Id())
-				if err != nil {
-					return nil, err
-				}
-
-				// Now, ids is guaranteed to have length 2, and d.Id() is well formed
-				d.Set("role_name", ids[0])
-				d.Set("auth_id", ids[1])
-
-				return []*schema.ResourceData{d}, nil
-			},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Update: true,
+				Delete: true,
+			}),
 		},
 	}
 }
 
-func resourceRoleMemberCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*Client)
+func (r *roleMemberResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// The provider hasn't been configured yet when Terraform validates the configuration
+	if req.ProviderData == nil {
+		return
+	}
+	r.client = req.ProviderData.(*Client)
+}
+
+func (r *roleMemberResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan roleMemberModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createTimeout, diags := plan.Timeouts.Create(ctx, 1*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, createTimeout)
+	defer cancel()
 
+	// Identities which aren't configured are unknown, and left out of the request
 	memberSettings := RoleMemberAddRequest{
-		Domain:   d.Get("domain").(string),
-		AuthId:   d.Get("auth_id").(string),
-		Uid:      d.Get("uid").(string),
-		Gid:      d.Get("gid").(string),
-		Sid:      d.Get("sid").(string),
-		Name:     d.Get("name").(string),
-		RoleName: d.Get("role_name").(string),
+		Domain:   plan.Domain.ValueString(),
+		AuthId:   plan.AuthId.ValueString(),
+		Uid:      plan.Uid.ValueString(),
+		Gid:      plan.Gid.ValueString(),
+		Sid:      plan.Sid.ValueString(),
+		Name:     plan.Name.ValueString(),
+		RoleName: plan.RoleName.ValueString(),
 	}
 
 	tflog.Info(ctx, fmt.Sprintf("Adding member to role with name %q; member info: %q",
@@ -133,69 +155,121 @@ func resourceRoleMemberCreate(ctx context.Context, d *schema.ResourceData, m int
 
 	tflog.Debug(ctx, fmt.Sprintf("Adding member with URL %s", addMemberToRoleUri))
 
-	joinResponse, err := DoRequest[RoleMemberAddRequest, RoleMemberResponse](ctx, c, POST, addMemberToRoleUri, &memberSettings)
+	joinResponse, err := DoRequest[RoleMemberAddRequest, RoleMemberResponse](ctx, r.client, POST, addMemberToRoleUri, &memberSettings)
 	if err != nil {
-		return diag.FromErr(err)
+		resp.Diagnostics.AddError("Unable to add the role member", err.Error())
+		return
 	}
 
 	// The format of a member resource ID is of the form {role_name}:{auth_id}
-	ids := make([]string, 2)
-	ids[0] = memberSettings.RoleName
-	ids[1] = joinResponse.AuthId
-	id, err := FormRoleMemberId(ids)
+	id, err := FormRoleMemberId([]string{memberSettings.RoleName, joinResponse.AuthId})
 	if err != nil {
-		return diag.FromErr(err)
+		resp.Diagnostics.AddError("Unable to add the role member", err.Error())
+		return
 	}
-	d.SetId(id)
+	plan.Id = types.StringValue(id)
 
-	return resourceGroupMemberRead(ctx, d, m)
+	if err := r.read(ctx, &plan); err != nil {
+		resp.Diagnostics.AddError("Unable to read the role member", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
 }
 
-func resourceRoleMemberRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*Client)
+func (r *roleMemberResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state roleMemberModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	ids, err := ParseRoleMemberId(d.Id())
-	if err != nil {
-		return diag.FromErr(err)
+	if err := r.read(ctx, &state); err != nil {
+		resp.Diagnostics.AddError("Unable to read the role member", err.Error())
+		return
 	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
 
-	readRoleMemberUri := RolesEndpoint + ids[0] + MembersSuffix + ids[1]
+// Every attribute but the timeouts requires replacing the member, so only the timeouts are updated
+func (r *roleMemberResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan roleMemberModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	tflog.Debug(ctx, fmt.Sprintf("Reading member with URL %s", readRoleMemberUri))
+	if err := r.read(ctx, &plan); err != nil {
+		resp.Diagnostics.AddError("Unable to read the role member", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
 
-	readResponse, err := DoRequest[RoleMemberResponse, RoleMemberResponse](ctx, c, GET, readRoleMemberUri, nil)
-	if err != nil {
-		return diag.FromErr(err)
+func (r *roleMemberResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state roleMemberModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	var errs ErrorCollection
+	deleteTimeout, diags := state.Timeouts.Delete(ctx, 1*time.Minute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, deleteTimeout)
+	defer cancel()
+
+	roleName := state.RoleName.ValueString()
+	authId := state.AuthId.ValueString()
+
+	deleteRoleMemberUri := RolesEndpoint + roleName + MembersSuffix + authId
 
-	errs.addMaybeError(d.Set("domain", readResponse.Domain))
-	errs.addMaybeError(d.Set("auth_id", readResponse.AuthId))
-	errs.addMaybeError(SetStringOrIntValue(d, "uid", readResponse.Uid))
-	errs.addMaybeError(SetStringOrIntValue(d, "gid", readResponse.Gid))
-	errs.addMaybeError(d.Set("sid", readResponse.Sid))
-	errs.addMaybeError(d.Set("name", readResponse.Name))
-	errs.addMaybeError(d.Set("role_name", ids[0]))
+	tflog.Debug(ctx, fmt.Sprintf("Removing member with id %q from role with name %q", authId, roleName))
 
-	return errs.diags
+	_, err := DoRequest[RoleMemberDeleteBody, RoleMemberDeleteBody](ctx, r.client, DELETE, deleteRoleMemberUri, nil)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to remove the role member", err.Error())
+	}
 }
 
-func resourceRoleMemberDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	c := m.(*Client)
+func (r *roleMemberResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// req.ID here is the last argument passed to the
+	// `terraform import RESOURCE_TYPE.RESOURCE_NAME RESOURCE_ID` command
+	ids, err := ParseRoleMemberId(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to import the role member", err.Error())
+		return
+	}
 
-	roleName := d.Get("role_name").(string)
-	authId := d.Get("auth_id").(string)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("role_name"), ids[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("auth_id"), ids[1])...)
+}
 
-	deleteRoleMemberUri := RolesEndpoint + roleName + MembersSuffix + authId
+func (r *roleMemberResource) read(ctx context.Context, member *roleMemberModel) error {
+	ids, err := ParseRoleMemberId(member.Id.ValueString())
+	if err != nil {
+		return err
+	}
 
-	tflog.Debug(ctx, fmt.Sprintf("Removing member with id %q from role with name %q", authId, roleName))
+	readRoleMemberUri := RolesEndpoint + ids[0] + MembersSuffix + ids[1]
 
-	_, err := DoRequest[RoleMemberDeleteBody, RoleMemberDeleteBody](ctx, c, DELETE, deleteRoleMemberUri, nil)
+	tflog.Debug(ctx, fmt.Sprintf("Reading member with URL %s", readRoleMemberUri))
+
+	readResponse, err := DoRequest[RoleMemberResponse, RoleMemberResponse](ctx, r.client, GET, readRoleMemberUri, nil)
 	if err != nil {
-		return diag.FromErr(err)
+		return err
 	}
 
+	member.Domain = types.StringValue(readResponse.Domain)
+	member.AuthId = types.StringValue(readResponse.AuthId)
+	member.Uid = readResponse.Uid.StringValue()
+	member.Gid = readResponse.Gid.StringValue()
+	member.Sid = types.StringValue(readResponse.Sid)
+	member.Name = types.StringValue(readResponse.Name)
+	member.RoleName = types.StringValue(ids[0])
+
 	return nil
 }
 
@@ -12,28 +12,35 @@ import (
 func TestAccAddRoleMember(t *testing.T) {
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:  func() { testAccPreCheck(t) },
-		Providers: testAccProviders,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccRoleMemberConfig(role1, userRoles1),
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckRoleMember(role1, userRoles1),
 					testAccValidateRoleMember(),
+					// Creating the member reads it back from the role, rather than as a group member
+					testAccCompareRoleMemberState(),
 				),
 			},
+			{
+				ResourceName:      "qumulo_role_member.test_member",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
 		},
 	})
 }
 
 var userRoles1 = UserBody{
-	Name:         "test_user_roles",
+	Name:         TestNamePrefix + "_user_roles",
 	PrimaryGroup: "514",
 	Password:     "Test1234",
 }
 
 var role1 = Role{
-	Name:        "Testers",
+	Name:        TestNamePrefix + "_testers",
 	Description: "This role is for testing purposes",
 	Privileges: []string{
 		"PRIVILEGE_AD_READ",
@@ -51,7 +58,7 @@ resource "qumulo_role" "test_role" {
 resource "qumulo_local_user" "test_user" {
 	name = %q
 	primary_group = %v
-	uid = "%v"
+	uid = %v
 	home_directory = %q
 	password = %q
 }
@@ -61,7 +68,7 @@ resource "qumulo_role_member" "test_member" {
 	role_name = qumulo_role.test_role.name
 }
   `, role.Name, role.Description, PrintTerraformListFromList(role.Privileges),
-		user.Name, user.PrimaryGroup, user.Uid, user.HomeDirectory, user.Password)
+		user.Name, user.PrimaryGroup, PrintTerraformNumberFromString(user.Uid), user.HomeDirectory, user.Password)
 }
 
 func testAccValidateRoleMember() resource.TestCheckFunc {
@@ -106,3 +113,35 @@ func testAccCheckRoleMember(role Role, user UserBody) resource.TestCheckFunc {
 		return nil
 	}
 }
+
+func testAccCompareRoleMemberState() resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		c := testAccProvider.Meta().(*Client)
+		ctx := context.Background()
+
+		memberResource, ok := s.RootModule().Resources["qumulo_role_member.test_member"]
+		if !ok {
+			return fmt.Errorf("role member resource not found, %v", memberResource)
+		}
+		attributes := memberResource.Primary.Attributes
+
+		readRoleMemberUri := RolesEndpoint + attributes["role_name"] + MembersSuffix + attributes["auth_id"]
+		remoteRoleMember, err := DoRequest[RoleMemberResponse, RoleMemberResponse](ctx, c, GET, readRoleMemberUri, nil)
+		if err != nil {
+			return err
+		}
+
+		for attribute, expected := range map[string]string{
+			"auth_id": remoteRoleMember.AuthId,
+			"domain":  remoteRoleMember.Domain,
+			"sid":     remoteRoleMember.Sid,
+			"name":    remoteRoleMember.Name,
+		} {
+			if attributes[attribute] != expected {
+				return fmt.Errorf("role member %s mismatch: Expected %v, got %v", attribute, expected, attributes[attribute])
+			}
+		}
+
+		return nil
+	}
+}
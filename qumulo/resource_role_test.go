@@ -13,8 +13,8 @@ import (
 func TestAccCreateRole(t *testing.T) {
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:  func() { testAccPreCheck(t) },
-		Providers: testAccProviders,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccRoleConfig(roleActors),
@@ -35,7 +35,7 @@ func TestAccCreateRole(t *testing.T) {
 }
 
 var roleActors = Role{
-	Name:        "Actors",
+	Name:        TestNamePrefix + "_actors",
 	Description: "This role is for testing purposes",
 	Privileges: []string{
 		"PRIVILEGE_AD_READ",
@@ -53,7 +53,7 @@ var roleActors = Role{
 }
 
 var roleActors2 = Role{
-	Name:        "Actors",
+	Name:        TestNamePrefix + "_actors",
 	Description: "This role is for testing purposes (part 2)",
 	Privileges: []string{
 		"PRIVILEGE_AD_READ",
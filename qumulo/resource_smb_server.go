@@ -2,7 +2,6 @@ package qumulo
 
 import (
 	"context"
-	"strconv"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
@@ -28,6 +27,17 @@ var SmbEncryptionSettings = []string{"NONE", "PREFERRED", "REQUIRED"}
 var SmbValidDialects = []string{"SMB2_DIALECT_2_002", "SMB2_DIALECT_2_1", "SMB2_DIALECT_3_0", "SMB2_DIALECT_3_11"}
 var SmbSnapshotDirectoryMode = []string{"DISABLED", "HIDDEN", "VISIBLE"}
 
+// Settings applied on destroy when on_destroy = "reset_to_defaults"
+var SmbServerDefaults = SmbServerBody{
+	SessionEncryption:               "NONE",
+	SupportedDialects:               SmbValidDialects,
+	HideSharesFromUnauthorizedUsers: false,
+	HideSharesFromUnauthorizedHosts: false,
+	SnapshotDirectoryMode:           "DISABLED",
+	BypassTraverseChecking:          false,
+	SigningRequired:                 false,
+}
+
 func resourceSmbServer() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceSmbServerCreate,
@@ -76,20 +86,28 @@ func resourceSmbServer() *schema.Resource {
 				Type:     schema.TypeBool,
 				Required: true,
 			},
-		},
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			"on_destroy":        onDestroySchema(Retain),
+			"previous_settings": previousSettingsSchema(),
+			"etag":              etagSchema(),
 		},
 	}
 }
 
 func resourceSmbServerCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+
+	if err := recordPreviousSettings[SmbServerBody](ctx, c, d, SmbServerEndpoint); err != nil {
+		return diag.FromErr(err)
+	}
+
 	err := setSmbServerSettings(ctx, d, m, PUT)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	d.SetId(strconv.FormatInt(time.Now().Unix(), 10))
+	if err := setSingletonId(ctx, d, m); err != nil {
+		return diag.FromErr(err)
+	}
 
 	return resourceSmbServerRead(ctx, d, m)
 }
@@ -104,6 +122,8 @@ func resourceSmbServerRead(ctx context.Context, d *schema.ResourceData, m interf
 		return diag.FromErr(err)
 	}
 
+	errs.addMaybeError(readETag(d, m, SmbServerEndpoint))
+
 	errs.addMaybeError(d.Set("session_encryption", smbSettings.SessionEncryption))
 	errs.addMaybeError(d.Set("supported_dialects", smbSettings.SupportedDialects))
 	errs.addMaybeError(d.Set("hide_shares_from_unauthorized_users", smbSettings.HideSharesFromUnauthorizedUsers))
@@ -116,6 +136,8 @@ func resourceSmbServerRead(ctx context.Context, d *schema.ResourceData, m interf
 }
 
 func resourceSmbServerUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	expectETag(d, m, SmbServerEndpoint)
+
 	err := setSmbServerSettings(ctx, d, m, PATCH)
 	if err != nil {
 		return diag.FromErr(err)
@@ -126,7 +148,9 @@ func resourceSmbServerUpdate(ctx context.Context, d *schema.ResourceData, m inte
 func resourceSmbServerDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	tflog.Info(ctx, "Deleting SMB settings resource")
 
-	return nil
+	c := m.(*Client)
+
+	return destroySingletonSettings(ctx, c, d, SmbServerEndpoint, &SmbServerDefaults)
 }
 
 func setSmbServerSettings(ctx context.Context, d *schema.ResourceData, m interface{}, method Method) error {
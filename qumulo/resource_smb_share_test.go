@@ -12,8 +12,8 @@ import (
 func TestAccAddSmbShare(t *testing.T) {
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:  func() { testAccPreCheck(t) },
-		Providers: testAccProviders,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: smbShare1,
@@ -33,8 +33,11 @@ func TestAccAddSmbShare(t *testing.T) {
 	})
 }
 
+const smbShareUser1 = TestNamePrefix + "_smb_user1"
+const smbShareUser2 = TestNamePrefix + "_smb_user2"
+
 var share1 = SmbShare{
-	ShareName:   "ShareForTesting",
+	ShareName:   TestNamePrefix + "_share",
 	FsPath:      "/",
 	Description: "Surely not",
 	Permissions: []SmbPermission{
@@ -42,14 +45,14 @@ var share1 = SmbShare{
 			Type: "ALLOWED",
 			Trustee: SmbTrustee{
 				Domain: "LOCAL",
-				Name:   "user1",
+				Name:   smbShareUser1,
 			},
 			Rights: []string{"READ", "WRITE", "CHANGE_PERMISSIONS"},
 		},
 		SmbPermission{
 			Type: "DENIED",
 			Trustee: SmbTrustee{
-				Name: "user2",
+				Name: smbShareUser2,
 			},
 			Rights: []string{"WRITE"},
 		},
@@ -67,13 +70,13 @@ var share1 = SmbShare{
 
 var smbShare1 = fmt.Sprintf(`
 resource "qumulo_local_user" "user1" {
-	name = "user1"
+	name = %q
 	primary_group = 514
 	password = "Test1234"
 }
 
 resource "qumulo_local_user" "user2" {
-	name = "user2"
+	name = %q
 	primary_group = 514
 	password = "Test1234"
 }
@@ -103,20 +106,20 @@ resource "qumulo_smb_share" "share" {
 		}
 		access_based_enumeration_enabled = %v
 		require_encryption = %v
-	  }`, share1.ShareName, share1.FsPath, share1.Description, share1.Permissions[0].Type, share1.Permissions[0].Trustee.Name,
+	  }`, smbShareUser1, smbShareUser2, share1.ShareName, share1.FsPath, share1.Description, share1.Permissions[0].Type, share1.Permissions[0].Trustee.Name,
 	PrintTerraformListFromList(share1.Permissions[0].Rights), share1.Permissions[1].Type, share1.Permissions[1].Trustee.Name,
 	PrintTerraformListFromList(share1.Permissions[1].Rights), share1.NetworkPermissions[0].Type, "[]",
 	PrintTerraformListFromList(share1.NetworkPermissions[0].Rights), share1.AccessBasedEnumEnabled, share1.RequireEncryption)
 
 var share1Updated = SmbShare{
-	ShareName:   "ShareForTesting",
+	ShareName:   TestNamePrefix + "_share",
 	FsPath:      "/",
 	Description: "Sharing is caring",
 	Permissions: []SmbPermission{
 		SmbPermission{
 			Type: "ALLOWED",
 			Trustee: SmbTrustee{
-				Name: "user1",
+				Name: smbShareUser1,
 			},
 			Rights: []string{"READ", "WRITE"},
 		},
@@ -124,7 +127,7 @@ var share1Updated = SmbShare{
 			Type: "DENIED",
 			Trustee: SmbTrustee{
 				Domain: "LOCAL",
-				Name:   "user2",
+				Name:   smbShareUser2,
 			},
 			Rights: []string{"WRITE"},
 		},
@@ -141,13 +144,13 @@ var share1Updated = SmbShare{
 }
 var smbShare1Updated = fmt.Sprintf(`
 resource "qumulo_local_user" "user1" {
-	name = "user1"
+	name = %q
 	primary_group = 514
 	password = "Test1234"
 }
 
 resource "qumulo_local_user" "user2" {
-	name = "user2"
+	name = %q
 	primary_group = 514
 	password = "Test1234"
 }
@@ -177,7 +180,7 @@ resource "qumulo_smb_share" "share" {
 		}
 		access_based_enumeration_enabled = %v
 		require_encryption = %v
-	  }`, share1Updated.ShareName, share1Updated.FsPath, share1Updated.Description, share1Updated.Permissions[0].Type,
+	  }`, smbShareUser1, smbShareUser2, share1Updated.ShareName, share1Updated.FsPath, share1Updated.Description, share1Updated.Permissions[0].Type,
 	share1Updated.Permissions[0].Trustee.Name, PrintTerraformListFromList(share1Updated.Permissions[0].Rights),
 	share1Updated.Permissions[1].Type, share1Updated.Permissions[1].Trustee.Name,
 	PrintTerraformListFromList(share1Updated.Permissions[1].Rights), share1Updated.NetworkPermissions[0].Type, "[]",
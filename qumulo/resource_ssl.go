@@ -2,9 +2,10 @@ package qumulo
 
 import (
 	"context"
-	"strconv"
+	"fmt"
 	"time"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -18,13 +19,15 @@ type SslRequest struct {
 	PrivateKey  string `json:"private_key"`
 }
 
-// TODO: Figure out what the proper response for an SSL update is
 type SslResponse struct {
-	Placeholder string `json:"placeholder"`
+	Certificate string `json:"certificate"`
 }
 
+// Attributes derived from the installed certificate, which change whenever the certificate does
+var sslCertificateComputedAttributes = []string{"fingerprint", "subject", "issuer", "subject_alternative_names", "not_before", "not_after", "chain"}
+
 func resourceSsl() *schema.Resource {
-	return &schema.Resource{
+	r := &schema.Resource{
 		CreateContext: resourceSslCreate,
 		ReadContext:   resourceSslRead,
 		UpdateContext: resourceSslUpdate,
@@ -38,19 +41,109 @@ func resourceSsl() *schema.Resource {
 
 		Schema: map[string]*schema.Schema{
 			"certificate": &schema.Schema{
-				Type:     schema.TypeString,
-				Required: true,
+				Type:             schema.TypeString,
+				Required:         true,
+				DiffSuppressFunc: suppressEquivalentCertificate,
 			},
 			"private_key": &schema.Schema{
+				Type:      schema.TypeString,
+				Required:  true,
+				Sensitive: true,
+				StateFunc: hashPrivateKey,
+			},
+			"expiry_warning_days": expiryWarningDaysSchema(),
+			"fingerprint": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"subject": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"issuer": &schema.Schema{
 				Type:     schema.TypeString,
-				Required: true,
+				Computed: true,
+			},
+			"subject_alternative_names": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"not_before": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"not_after": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"chain": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"subject": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"issuer": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"not_after": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"fingerprint": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
 			},
 		},
 
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+		CustomizeDiff: resourceSslCustomizeDiff,
+	}
+
+	// Version 0 states were identified by the Unix time at which they were created, had none of the
+	// details of the certificate, and held the private key itself. singletonResource leaves this
+	// upgrader in place.
+	r.SchemaVersion = 1
+	r.StateUpgraders = []schema.StateUpgrader{
+		{
+			Version: 0,
+			Type: earlierStateType(r, map[string]cty.Type{
+				"expiry_warning_days":       cty.NilType,
+				"subject":                   cty.NilType,
+				"issuer":                    cty.NilType,
+				"not_before":                cty.NilType,
+				"not_after":                 cty.NilType,
+				"fingerprint":               cty.NilType,
+				"subject_alternative_names": cty.NilType,
+				"chain":                     cty.NilType,
+			}),
+			Upgrade: upgradeWithDefaults(r, upgradeSslV0),
 		},
 	}
+
+	return r
+}
+
+// Replaces the private key in a version 0 state with the hash which its StateFunc now stores, so that
+// the next plan doesn't update the certificate
+func upgradeSslV0(ctx context.Context, rawState map[string]interface{}, m interface{}) (map[string]interface{}, error) {
+	rawState, err := upgradeSingletonIdV0(ctx, rawState, m)
+	if err != nil {
+		return nil, err
+	}
+
+	if privateKey, ok := rawState["private_key"].(string); ok {
+		rawState["private_key"] = hashPrivateKey(privateKey)
+	}
+	return rawState, nil
 }
 
 func resourceSslCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -59,23 +152,102 @@ func resourceSslCreate(ctx context.Context, d *schema.ResourceData, m interface{
 		return diag.FromErr(err)
 	}
 
-	d.SetId(strconv.FormatInt(time.Now().Unix(), 10))
+	if err := setSingletonId(ctx, d, m); err != nil {
+		return diag.FromErr(err)
+	}
 
 	return resourceSslRead(ctx, d, m)
 }
 
 func resourceSslRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	return nil
-}
+	c := m.(*Client)
 
-func resourceSslUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	err := setSslSettings(ctx, d, m)
+	var errs ErrorCollection
+
+	ssl, err := DoRequest[SslRequest, SslResponse](ctx, c, GET, SslEndpoint, nil)
 	if err != nil {
 		return diag.FromErr(err)
 	}
+
+	// Setting the certificate read back from the cluster lets the fingerprint comparison in
+	// suppressEquivalentCertificate pick up a certificate replaced outside of Terraform
+	errs.addMaybeError(d.Set("certificate", ssl.Certificate))
+
+	certs, err := parseCertificateChain(ssl.Certificate)
+	if err != nil {
+		for _, key := range []string{"fingerprint", "subject", "issuer", "not_before", "not_after"} {
+			errs.addMaybeError(d.Set(key, ""))
+		}
+		errs.addMaybeError(d.Set("subject_alternative_names", nil))
+		errs.addMaybeError(d.Set("chain", nil))
+
+		return append(errs.diags, unparseableCertificateWarning("certificate", err)...)
+	}
+	leaf := certs[0]
+
+	errs.addMaybeError(d.Set("fingerprint", certificateFingerprint(leaf)))
+	errs.addMaybeError(d.Set("subject", leaf.Subject.String()))
+	errs.addMaybeError(d.Set("issuer", leaf.Issuer.String()))
+	errs.addMaybeError(d.Set("subject_alternative_names", certificateSubjectAlternativeNames(leaf)))
+	errs.addMaybeError(d.Set("not_before", leaf.NotBefore.UTC().Format(time.RFC3339)))
+	errs.addMaybeError(d.Set("not_after", leaf.NotAfter.UTC().Format(time.RFC3339)))
+	errs.addMaybeError(d.Set("chain", flattenCertificateChain(certs)))
+
+	return append(errs.diags, certificateExpiryWarning(leaf, d.Get("expiry_warning_days").(int), time.Now())...)
+}
+
+func resourceSslUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// Changing only expiry_warning_days doesn't need the certificate to be installed again
+	if d.HasChanges("certificate", "private_key") {
+		err := setSslSettings(ctx, d, m)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
 	return resourceSslRead(ctx, d, m)
 }
 
+func resourceSslCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.HasChanges("certificate", "private_key") {
+		return nil
+	}
+
+	// private_key is hashed by its StateFunc, so the PEM has to come from the raw config
+	config := d.GetRawConfig()
+	if !config.IsNull() {
+		certificate := config.GetAttr("certificate")
+		privateKey := config.GetAttr("private_key")
+
+		if certificate.IsKnown() && !certificate.IsNull() {
+			certs, err := parseCertificateChain(certificate.AsString())
+			if err != nil {
+				return fmt.Errorf("invalid certificate: %w", err)
+			}
+			if err := validateCertificateChain(certs, time.Now()); err != nil {
+				return fmt.Errorf("invalid certificate: %w", err)
+			}
+
+			if privateKey.IsKnown() && !privateKey.IsNull() {
+				if err := validatePrivateKey(privateKey.AsString(), certs[0]); err != nil {
+					return fmt.Errorf("invalid private key: %w", err)
+				}
+			}
+		}
+	}
+
+	if !d.HasChange("certificate") {
+		return nil
+	}
+
+	for _, attribute := range sslCertificateComputedAttributes {
+		if err := d.SetNewComputed(attribute); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func resourceSslDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	tflog.Info(ctx, "Deleting SSL settings resource")
 
@@ -85,9 +257,21 @@ func resourceSslDelete(ctx context.Context, d *schema.ResourceData, m interface{
 func setSslSettings(ctx context.Context, d *schema.ResourceData, m interface{}) error {
 	c := m.(*Client)
 
+	// private_key is hashed by its StateFunc, so d.Get would return the hash rather than the PEM, and
+	// certificate may hold the equivalent certificate read back from the cluster
+	config := d.GetRawConfig()
+	if config.IsNull() {
+		return fmt.Errorf("the certificate and private key are only available from the configuration")
+	}
+	certificate := config.GetAttr("certificate")
+	privateKey := config.GetAttr("private_key")
+	if !certificate.IsKnown() || certificate.IsNull() || !privateKey.IsKnown() || privateKey.IsNull() {
+		return fmt.Errorf("the certificate and private key must be set in the configuration")
+	}
+
 	sslConfig := SslRequest{
-		Certificate: d.Get("certificate").(string),
-		PrivateKey:  d.Get("private_key").(string),
+		Certificate: certificate.AsString(),
+		PrivateKey:  privateKey.AsString(),
 	}
 
 	tflog.Debug(ctx, "Updating SSL settings")
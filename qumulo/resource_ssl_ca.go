@@ -2,10 +2,11 @@ package qumulo
 
 import (
 	"context"
-	"strconv"
+	"fmt"
 	"strings"
 	"time"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -19,7 +20,7 @@ type SslCaBody struct {
 }
 
 func resourceSslCa() *schema.Resource {
-	return &schema.Resource{
+	r := &schema.Resource{
 		CreateContext: resourceSslCaCreate,
 		ReadContext:   resourceSslCaRead,
 		UpdateContext: resourceSslCaUpdate,
@@ -36,12 +37,26 @@ func resourceSslCa() *schema.Resource {
 				Type:     schema.TypeString,
 				Required: true,
 			},
+			"expiry_warning_days": expiryWarningDaysSchema(),
 		},
 
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+		CustomizeDiff: resourceSslCaCustomizeDiff,
+	}
+
+	// Version 0 states were identified by the Unix time at which they were created, and had no
+	// expiry_warning_days. singletonResource leaves this upgrader in place.
+	r.SchemaVersion = 1
+	r.StateUpgraders = []schema.StateUpgrader{
+		{
+			Version: 0,
+			Type: earlierStateType(r, map[string]cty.Type{
+				"expiry_warning_days": cty.NilType,
+			}),
+			Upgrade: upgradeWithDefaults(r, upgradeSingletonIdV0),
 		},
 	}
+
+	return r
 }
 
 func resourceSslCaCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -50,7 +65,9 @@ func resourceSslCaCreate(ctx context.Context, d *schema.ResourceData, m interfac
 		return diag.FromErr(err)
 	}
 
-	d.SetId(strconv.FormatInt(time.Now().Unix(), 10))
+	if err := setSingletonId(ctx, d, m); err != nil {
+		return diag.FromErr(err)
+	}
 
 	return resourceSslCaRead(ctx, d, m)
 }
@@ -77,7 +94,17 @@ func resourceSslCaRead(ctx context.Context, d *schema.ResourceData, m interface{
 		return diag.FromErr(err)
 	}
 
-	return nil
+	// As when the endpoint returns 404, there is nothing to warn about without a certificate
+	if cert.CaCertificate == "" {
+		return nil
+	}
+
+	certs, err := parseCertificateChain(cert.CaCertificate)
+	if err != nil {
+		return unparseableCertificateWarning("CA certificate", err)
+	}
+
+	return certificateExpiryWarning(certs[0], d.Get("expiry_warning_days").(int), time.Now())
 }
 
 func resourceSslCaUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -88,6 +115,23 @@ func resourceSslCaUpdate(ctx context.Context, d *schema.ResourceData, m interfac
 	return resourceSslCaRead(ctx, d, m)
 }
 
+func resourceSslCaCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.HasChange("ca_certificate") || !d.NewValueKnown("ca_certificate") {
+		return nil
+	}
+
+	certs, err := parseCertificateChain(d.Get("ca_certificate").(string))
+	if err != nil {
+		return fmt.Errorf("invalid CA certificate: %w", err)
+	}
+	// A CA bundle may hold several unrelated roots, so there is no chain order to check
+	if err := validateCertificateValidity(certs, time.Now()); err != nil {
+		return fmt.Errorf("invalid CA certificate: %w", err)
+	}
+
+	return nil
+}
+
 func resourceSslCaDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	tflog.Info(ctx, "Deleting SSL CA Settings")
 	c := m.(*Client)
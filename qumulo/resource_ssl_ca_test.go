@@ -2,10 +2,14 @@ package qumulo
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"regexp"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
@@ -13,8 +17,8 @@ import (
 func TestAccSetSslCa(t *testing.T) {
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:  func() { testAccPreCheck(t) },
-		Providers: testAccProviders,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{ // Reset state to default
 				Config: defaultSslCaConfig,
@@ -30,16 +34,36 @@ func TestAccSetSslCa(t *testing.T) {
 	})
 }
 
+func TestReadSslCaUnparseable(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(invalidCert)
+	}))
+	defer server.Close()
+
+	r := resourceSslCa()
+	d := r.TestResourceData()
+	d.SetId(testClusterUuid)
+
+	c := &Client{HostURL: server.URL, HTTPClient: server.Client()}
+	diags := r.ReadContext(context.Background(), d, c)
+	if len(diags) != 1 || diags[0].Severity != diag.Warning {
+		t.Fatalf("expected a warning reading a CA certificate which can't be parsed, got %v", diags)
+	}
+	if d.Get("ca_certificate") != invalidCert.CaCertificate {
+		t.Errorf("expected the CA certificate to be kept in state, got %q", d.Get("ca_certificate"))
+	}
+}
+
 func TestAccSetSslCa_ExpectError(t *testing.T) {
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:  func() { testAccPreCheck(t) },
-		Providers: testAccProviders,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccSslCaConfig(invalidCert),
-				// Invalid certificate should raise an error
-				ExpectError: regexp.MustCompile("ssl_certificate_invalid_error"),
+				// Invalid certificate should be rejected at plan time
+				ExpectError: regexp.MustCompile("invalid CA certificate"),
 			},
 		},
 	})
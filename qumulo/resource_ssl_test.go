@@ -0,0 +1,129 @@
+package qumulo
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccUpdateSslCertificateOnly(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("unable to generate key: %v", err)
+	}
+	notAfter := time.Now().Add(24 * time.Hour)
+	_, certPem, keyPem := testIssueCertificateForKey(t, "cluster.example.com", notAfter, key, nil, nil)
+	// The same key with a renewed certificate
+	_, renewedCertPem, _ := testIssueCertificateForKey(t, "renewed.example.com", notAfter, key, nil, nil)
+
+	var installed int
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheckFakeCluster(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSslConfig(certPem, keyPem, DefaultExpiryWarningDays),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSslInstalled(certPem, keyPem, &installed),
+					resource.TestCheckResourceAttr("qumulo_ssl_cert.cert", "subject", "CN=cluster.example.com,O=Qumulo"),
+				),
+			},
+			{
+				Config: testAccSslConfig(renewedCertPem, keyPem, DefaultExpiryWarningDays),
+				Check: resource.ComposeTestCheckFunc(
+					// The key is unchanged, so the PEM has to come from the configuration rather than the state
+					testAccCheckSslInstalled(renewedCertPem, keyPem, &installed),
+					resource.TestCheckResourceAttr("qumulo_ssl_cert.cert", "subject", "CN=renewed.example.com,O=Qumulo"),
+				),
+			},
+			{
+				Config: testAccSslConfig(renewedCertPem, keyPem, 7),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckSslNotInstalledAgain(&installed),
+					resource.TestCheckResourceAttr("qumulo_ssl_cert.cert", "expiry_warning_days", "7"),
+				),
+			},
+		},
+	})
+}
+
+func TestReadSslCertificateUnparseable(t *testing.T) {
+	for _, certificate := range []string{"", "-----BEGIN CERTIFICATE-----\nMIIB\n-----END CERTIFICATE-----\n"} {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			json.NewEncoder(w).Encode(SslResponse{Certificate: certificate})
+		}))
+
+		r := resourceSsl()
+		d := r.TestResourceData()
+		d.SetId(testClusterUuid)
+		d.Set("subject", "CN=cluster.example.com,O=Qumulo")
+		d.Set("chain", []interface{}{map[string]interface{}{"subject": "CN=cluster.example.com,O=Qumulo"}})
+
+		c := &Client{HostURL: server.URL, HTTPClient: server.Client()}
+		diags := r.ReadContext(context.Background(), d, c)
+		server.Close()
+
+		if len(diags) != 1 || diags[0].Severity != diag.Warning {
+			t.Fatalf("expected a warning reading the certificate %q, got %v", certificate, diags)
+		}
+		if d.Id() != testClusterUuid || d.Get("certificate") != certificate {
+			t.Errorf("expected the certificate %q to be kept in state, got %q", certificate, d.Get("certificate"))
+		}
+		if d.Get("subject") != "" || len(d.Get("chain").([]interface{})) != 0 {
+			t.Errorf("expected the details of the certificate %q to be cleared, got %v and %v", certificate,
+				d.Get("subject"), d.Get("chain"))
+		}
+	}
+}
+
+func testAccSslConfig(certificate string, privateKey string, expiryWarningDays int) string {
+	return fmt.Sprintf(`
+resource "qumulo_ssl_cert" "cert" {
+	certificate = <<CERTDELIM
+%v
+CERTDELIM
+	private_key = <<KEYDELIM
+%v
+KEYDELIM
+	expiry_warning_days = %v
+}
+  `, certificate, privateKey, expiryWarningDays)
+}
+
+// Checks the body the fake cluster was last sent, and records how many times it was sent
+func testAccCheckSslInstalled(certificate string, privateKey string, installed *int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		body, version := testFakeCluster.object(SslEndpoint)
+
+		// Heredocs end with a newline
+		if body["certificate"] != certificate+"\n" {
+			return fmt.Errorf("certificate mismatch: Expected %q, got %q", certificate+"\n", body["certificate"])
+		}
+		if body["private_key"] != privateKey+"\n" {
+			return fmt.Errorf("private key mismatch: Expected the PEM, got %q", body["private_key"])
+		}
+
+		*installed = version
+		return nil
+	}
+}
+
+func testAccCheckSslNotInstalledAgain(installed *int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if _, version := testFakeCluster.object(SslEndpoint); version != *installed {
+			return fmt.Errorf("expected the certificate not to be installed again")
+		}
+		return nil
+	}
+}
@@ -2,7 +2,6 @@ package qumulo
 
 import (
 	"context"
-	"strconv"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
@@ -19,6 +18,13 @@ type SyslogConfigBody struct {
 	ServerPort    int    `json:"server_port"`
 }
 
+// Settings applied on destroy when on_destroy = "reset_to_defaults"
+var SyslogConfigDefaults = SyslogConfigBody{
+	Enabled:       false,
+	ServerAddress: "",
+	ServerPort:    0,
+}
+
 func resourceSyslog() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceSyslogCreate,
@@ -38,8 +44,9 @@ func resourceSyslog() *schema.Resource {
 				Required: true,
 			},
 			"server_address": &schema.Schema{
-				Type:     schema.TypeString,
-				Optional: true,
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.Any(validation.StringIsEmpty, validateHostOrIpAddress)),
 			},
 			"server_port": &schema.Schema{
 				Type:             schema.TypeInt,
@@ -47,10 +54,9 @@ func resourceSyslog() *schema.Resource {
 				Default:          0,
 				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(0)),
 			},
-		},
-
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			"on_destroy":        onDestroySchema(Retain),
+			"previous_settings": previousSettingsSchema(),
+			"etag":              etagSchema(),
 		},
 	}
 }
@@ -58,12 +64,18 @@ func resourceSyslog() *schema.Resource {
 func resourceSyslogCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*Client)
 
+	if err := recordPreviousSettings[SyslogConfigBody](ctx, c, d, SyslogConfigEndpoint); err != nil {
+		return diag.FromErr(err)
+	}
+
 	err := modifySyslogConfig(ctx, c, d, PUT)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	d.SetId(strconv.FormatInt(time.Now().Unix(), 10))
+	if err := setSingletonId(ctx, d, m); err != nil {
+		return diag.FromErr(err)
+	}
 
 	return resourceSyslogRead(ctx, d, m)
 }
@@ -78,6 +90,8 @@ func resourceSyslogRead(ctx context.Context, d *schema.ResourceData, m interface
 		return diag.FromErr(err)
 	}
 
+	errs.addMaybeError(readETag(d, m, SyslogConfigEndpoint))
+
 	errs.addMaybeError(d.Set("enabled", syslogConfig.Enabled))
 	errs.addMaybeError(d.Set("server_address", syslogConfig.ServerAddress))
 	errs.addMaybeError(d.Set("server_port", syslogConfig.ServerPort))
@@ -86,6 +100,8 @@ func resourceSyslogRead(ctx context.Context, d *schema.ResourceData, m interface
 }
 
 func resourceSyslogUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	expectETag(d, m, SyslogConfigEndpoint)
+
 	c := m.(*Client)
 
 	err := modifySyslogConfig(ctx, c, d, PATCH)
@@ -99,7 +115,9 @@ func resourceSyslogUpdate(ctx context.Context, d *schema.ResourceData, m interfa
 func resourceSyslogDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	tflog.Info(ctx, "Deleting audit log syslog resource")
 
-	return nil
+	c := m.(*Client)
+
+	return destroySingletonSettings(ctx, c, d, SyslogConfigEndpoint, &SyslogConfigDefaults)
 }
 
 func modifySyslogConfig(ctx context.Context, c *Client, d *schema.ResourceData, method Method) error {
@@ -12,8 +12,8 @@ import (
 
 func TestAccCreateSyslogAuditLog(t *testing.T) {
 	resource.Test(t, resource.TestCase{
-		PreCheck:  func() { testAccPreCheck(t) },
-		Providers: testAccProviders,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{ // Reset state to default
 				Config: testAccSyslogConfig(defaultSyslogConfig),
@@ -40,6 +40,27 @@ func TestAccCreateSyslogAuditLog(t *testing.T) {
 	})
 }
 
+func TestAccSyslogResetToDefaultsOnDestroy(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckSyslogSettings(SyslogConfigDefaults),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSyslogConfigOnDestroy(testSyslogConfig, ResetToDefaults),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCompareSyslogResource(testSyslogConfig),
+					testAccCheckSyslogSettings(testSyslogConfig),
+					resource.TestCheckResourceAttr("qumulo_syslog.test_syslog_settings", "on_destroy",
+						ResetToDefaults.String()),
+					// Only restore_previous needs the previous settings
+					resource.TestCheckNoResourceAttr("qumulo_syslog.test_syslog_settings", "previous_settings"),
+				),
+			},
+		},
+	})
+}
+
 var defaultSyslogConfig = SyslogConfigBody{
 	Enabled:       false,
 	ServerAddress: "",
@@ -62,6 +83,17 @@ resource "qumulo_syslog" "test_syslog_settings" {
   `, settings.Enabled, settings.ServerAddress, settings.ServerPort)
 }
 
+func testAccSyslogConfigOnDestroy(settings SyslogConfigBody, onDestroy OnDestroy) string {
+	return fmt.Sprintf(`
+resource "qumulo_syslog" "test_syslog_settings" {
+	enabled = %v
+	server_address = %q
+	server_port = %v
+	on_destroy = %q
+}
+  `, settings.Enabled, settings.ServerAddress, settings.ServerPort, onDestroy)
+}
+
 func testAccCompareSyslogResource(settings SyslogConfigBody) resource.TestCheckFunc {
 	return resource.ComposeTestCheckFunc(
 		resource.TestCheckResourceAttr("qumulo_syslog.test_syslog_settings", "enabled",
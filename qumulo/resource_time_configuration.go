@@ -2,7 +2,6 @@ package qumulo
 
 import (
 	"context"
-	"strconv"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
@@ -13,12 +12,18 @@ import (
 
 const TimeConfigurationEndpoint = "/v1/time/settings"
 
-type TimeConfigurationBody struct {
-	UseAdForPrimary bool     `json:"use_ad_for_primary"`
-	NtpServers      []string `json:"ntp_servers"`
+// Settings applied on destroy when on_destroy = "reset_to_defaults"
+var TimeConfigurationDefaults = TimeConfigurationBody{
+	UseAdForPrimary: false,
+	NtpServers:      []string{"0.qumulo.pool.ntp.org", "1.qumulo.pool.ntp.org"},
 }
 
 func resourceTimeConfiguration() *schema.Resource {
+	timeSchema := timeConfigurationBodySchema()
+	timeSchema["on_destroy"] = onDestroySchema(Retain)
+	timeSchema["previous_settings"] = previousSettingsSchema()
+	timeSchema["etag"] = etagSchema()
+
 	return &schema.Resource{
 		CreateContext: resourceTimeConfigurationCreate,
 		ReadContext:   resourceTimeConfigurationRead,
@@ -31,33 +36,25 @@ func resourceTimeConfiguration() *schema.Resource {
 			Delete: schema.DefaultTimeout(1 * time.Minute),
 		},
 
-		Schema: map[string]*schema.Schema{
-			"use_ad_for_primary": &schema.Schema{
-				Type:     schema.TypeBool,
-				Required: true,
-			},
-			"ntp_servers": &schema.Schema{
-				Type:     schema.TypeList,
-				Required: true,
-				Elem: &schema.Schema{
-					Type: schema.TypeString,
-				},
-			},
-		},
-
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
-		},
+		Schema: timeSchema,
 	}
 }
 
 func resourceTimeConfigurationCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+
+	if err := recordPreviousSettings[TimeConfigurationBody](ctx, c, d, TimeConfigurationEndpoint); err != nil {
+		return diag.FromErr(err)
+	}
+
 	err := setTimeConfiguration(ctx, d, m, PUT)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	d.SetId(strconv.FormatInt(time.Now().Unix(), 10))
+	if err := setSingletonId(ctx, d, m); err != nil {
+		return diag.FromErr(err)
+	}
 
 	return resourceTimeConfigurationRead(ctx, d, m)
 }
@@ -65,20 +62,21 @@ func resourceTimeConfigurationCreate(ctx context.Context, d *schema.ResourceData
 func resourceTimeConfigurationRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	c := m.(*Client)
 
-	var errs ErrorCollection
-
 	timeConfig, err := DoRequest[TimeConfigurationBody, TimeConfigurationBody](ctx, c, GET, TimeConfigurationEndpoint, nil)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	errs.addMaybeError(d.Set("use_ad_for_primary", timeConfig.UseAdForPrimary))
-	errs.addMaybeError(d.Set("ntp_servers", timeConfig.NtpServers))
+	if err := readETag(d, m, TimeConfigurationEndpoint); err != nil {
+		return diag.FromErr(err)
+	}
 
-	return errs.diags
+	return flattenTimeConfigurationBody(d, timeConfig)
 }
 
 func resourceTimeConfigurationUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	expectETag(d, m, TimeConfigurationEndpoint)
+
 	err := setTimeConfiguration(ctx, d, m, PATCH)
 	if err != nil {
 		return diag.FromErr(err)
@@ -90,18 +88,15 @@ func resourceTimeConfigurationUpdate(ctx context.Context, d *schema.ResourceData
 func resourceTimeConfigurationDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	tflog.Info(ctx, "Deleting time configuration resource")
 
-	return nil
+	c := m.(*Client)
+
+	return destroySingletonSettings(ctx, c, d, TimeConfigurationEndpoint, &TimeConfigurationDefaults)
 }
 
 func setTimeConfiguration(ctx context.Context, d *schema.ResourceData, m interface{}, method Method) error {
 	c := m.(*Client)
 
-	ntpServers := InterfaceSliceToStringSlice(d.Get("ntp_servers").([]interface{}))
-
-	timeConfigurationRequest := TimeConfigurationBody{
-		UseAdForPrimary: d.Get("use_ad_for_primary").(bool),
-		NtpServers:      ntpServers,
-	}
+	timeConfigurationRequest := expandTimeConfigurationBody(d)
 
 	tflog.Debug(ctx, "Updating time configuration")
 	_, err := DoRequest[TimeConfigurationBody, TimeConfigurationBody](ctx, c, method, TimeConfigurationEndpoint, &timeConfigurationRequest)
@@ -2,9 +2,10 @@ package qumulo
 
 import (
 	"context"
-	"strconv"
+	"fmt"
 	"time"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -28,7 +29,7 @@ type WebUiTimeout struct {
 }
 
 func resourceWebUi() *schema.Resource {
-	return &schema.Resource{
+	r := &schema.Resource{
 		CreateContext: resourceWebUiCreate,
 		ReadContext:   resourceWebUiRead,
 		UpdateContext: resourceWebUiUpdate,
@@ -42,39 +43,78 @@ func resourceWebUi() *schema.Resource {
 
 		Schema: map[string]*schema.Schema{
 			"inactivity_timeout": &schema.Schema{
-				Type:     schema.TypeList,
-				Required: true,
-				MinItems: 1,
-				MaxItems: 1,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"nanoseconds": &schema.Schema{
-							Type:     schema.TypeString,
-							Required: true,
-						},
-					},
-				},
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateFunc:     validateDuration,
+				DiffSuppressFunc: suppressEquivalentDuration,
+			},
+			"inactivity_timeout_nanoseconds": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
 			},
 			"login_banner": &schema.Schema{
 				Type:     schema.TypeString,
 				Optional: true,
 				Default:  nil,
 			},
+			"on_destroy":        onDestroySchema(ResetToDefaults),
+			"previous_settings": previousSettingsSchema(),
+			"etag":              etagSchema(),
 		},
 
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+		CustomizeDiff: planCanonicalNumber("inactivity_timeout", "inactivity_timeout_nanoseconds", parseDurationNanoseconds),
+	}
+
+	// The timeout was a block of nanoseconds until version 2. singletonResource adds the upgrader from
+	// version 0.
+	r.SchemaVersion = 2
+	r.StateUpgraders = []schema.StateUpgrader{
+		{
+			Version: 1,
+			Type: earlierStateType(r, map[string]cty.Type{
+				"inactivity_timeout":             cty.List(cty.Object(map[string]cty.Type{"nanoseconds": cty.String})),
+				"inactivity_timeout_nanoseconds": cty.NilType,
+			}),
+			Upgrade: upgradeWithDefaults(r, upgradeWebUiV1),
 		},
 	}
+
+	return r
+}
+
+func upgradeWebUiV1(ctx context.Context, rawState map[string]interface{}, m interface{}) (map[string]interface{}, error) {
+	timeouts, _ := rawState["inactivity_timeout"].([]interface{})
+	if len(timeouts) != 1 {
+		return nil, fmt.Errorf("unable to upgrade inactivity_timeout: expected one block, got %d", len(timeouts))
+	}
+	timeout, _ := timeouts[0].(map[string]interface{})
+	nanoseconds, _ := timeout["nanoseconds"].(string)
+
+	d, err := parseNanoseconds(nanoseconds)
+	if err != nil {
+		return nil, fmt.Errorf("unable to upgrade inactivity_timeout: %w", err)
+	}
+	rawState["inactivity_timeout"] = formatDuration(d)
+	rawState["inactivity_timeout_nanoseconds"] = d.Nanoseconds()
+
+	return rawState, nil
 }
 
 func resourceWebUiCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*Client)
+
+	if err := recordPreviousSettings[WebUiBody](ctx, c, d, WebUiEndpoint); err != nil {
+		return diag.FromErr(err)
+	}
+
 	err := setWebUi(ctx, d, m, PUT)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	d.SetId(strconv.FormatInt(time.Now().Unix(), 10))
+	if err := setSingletonId(ctx, d, m); err != nil {
+		return diag.FromErr(err)
+	}
 
 	return resourceWebUiRead(ctx, d, m)
 }
@@ -89,20 +129,25 @@ func resourceWebUiRead(ctx context.Context, d *schema.ResourceData, m interface{
 		return diag.FromErr(err)
 	}
 
-	var tfList []interface{}
-
-	tfMap := map[string]interface{}{}
-	tfMap["nanoseconds"] = uiConfig.InactivityTimeout.Nanoseconds
+	errs.addMaybeError(readETag(d, m, WebUiEndpoint))
 
-	tfList = append(tfList, tfMap)
-
-	errs.addMaybeError(d.Set("inactivity_timeout", tfList))
+	inactivityTimeout, err := parseNanoseconds(uiConfig.InactivityTimeout.Nanoseconds)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("unexpected inactivity timeout: %w", err))
+	}
+	// Keeps the way the timeout is written in the configuration unless it changed
+	if current, err := time.ParseDuration(d.Get("inactivity_timeout").(string)); err != nil || current != inactivityTimeout {
+		errs.addMaybeError(d.Set("inactivity_timeout", formatDuration(inactivityTimeout)))
+	}
+	errs.addMaybeError(d.Set("inactivity_timeout_nanoseconds", inactivityTimeout.Nanoseconds()))
 	errs.addMaybeError(d.Set("login_banner", *uiConfig.LoginBanner))
 
 	return errs.diags
 }
 
 func resourceWebUiUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	expectETag(d, m, WebUiEndpoint)
+
 	err := setWebUi(ctx, d, m, PATCH)
 	if err != nil {
 		return diag.FromErr(err)
@@ -116,13 +161,17 @@ func resourceWebUiDelete(ctx context.Context, d *schema.ResourceData, m interfac
 
 	c := m.(*Client)
 
+	if d.Get("on_destroy").(string) != ResetToDefaults.String() {
+		return destroySingletonSettings[WebUiBody](ctx, c, d, WebUiEndpoint, nil)
+	}
+
 	// Using a different struct here is a workaround. We have to pass in something that will be represented
 	// as null in json, and a nil string pointer is the simplest way to do that.
 	nullTimeout := WebUiEmpty{}
 	nullTimeout.InactivityTimeout = nil
 	nullTimeout.LoginBanner = nil
 
-	_, err := DoRequest[WebUiEmpty, WebUiEmpty](ctx, c, PATCH, WebUiEndpoint, &nullTimeout)
+	_, err := DoRequest[WebUiEmpty, WebUiBody](ctx, c, PATCH, WebUiEndpoint, &nullTimeout)
 
 	return diag.FromErr(err)
 }
@@ -130,20 +179,13 @@ func resourceWebUiDelete(ctx context.Context, d *schema.ResourceData, m interfac
 func setWebUi(ctx context.Context, d *schema.ResourceData, m interface{}, method Method) error {
 	c := m.(*Client)
 
-	tfMap, ok := d.Get("inactivity_timeout").([]interface{})[0].(map[string]interface{})
-	if !ok {
-		tflog.Debug(ctx, "Error getting web UI resource")
-	}
-
-	timeout := WebUiTimeout{}
-	if v, ok := tfMap["nanoseconds"].(string); ok {
-		timeout.Nanoseconds = v
-	} else {
-		tflog.Debug(ctx, "Error getting web UI timeout")
+	inactivityTimeout, err := time.ParseDuration(d.Get("inactivity_timeout").(string))
+	if err != nil {
+		return err
 	}
 
 	webUiRequest := WebUiBody{
-		InactivityTimeout: timeout,
+		InactivityTimeout: WebUiTimeout{Nanoseconds: formatNanoseconds(inactivityTimeout)},
 	}
 
 	if v, ok := d.Get("login_banner").(string); ok {
@@ -151,7 +193,7 @@ func setWebUi(ctx context.Context, d *schema.ResourceData, m interface{}, method
 	}
 
 	tflog.Debug(ctx, "Updating web UI")
-	_, err := DoRequest[WebUiBody, WebUiBody](ctx, c, method, WebUiEndpoint, &webUiRequest)
+	_, err = DoRequest[WebUiBody, WebUiBody](ctx, c, method, WebUiEndpoint, &webUiRequest)
 
 	return err
 }
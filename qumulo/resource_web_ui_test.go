@@ -13,14 +13,45 @@ import (
 func TestAccSetWebUi(t *testing.T) {
 
 	resource.Test(t, resource.TestCase{
-		PreCheck:  func() { testAccPreCheck(t) },
-		Providers: testAccProviders,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
-				Config: testAccWebUiConfig(testingWebUi),
+				Config: testAccWebUiConfig(testingWebUi, "15m"),
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckWebUi(testingWebUi),
-					testAccCompareWebUiSettings(testingWebUi),
+					testAccCompareWebUiSettings(testingWebUi, "15m"),
+				),
+			},
+			{
+				// Durations as long as the one in the state don't change it
+				Config:   testAccWebUiConfig(testingWebUi, "900s"),
+				PlanOnly: true,
+			},
+			{
+				Config: testAccWebUiConfig(updatedWebUi, "1h30m"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckWebUi(updatedWebUi),
+					testAccCompareWebUiSettings(updatedWebUi, "1h30m"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccWebUiResetToDefaultsOnDestroy(t *testing.T) {
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		// The PATCH which resets the settings answers with the settings, which have to be decoded as such
+		CheckDestroy: testAccCheckWebUi(defaultWebUi),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccWebUiConfig(updatedWebUi, "1h30m"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckWebUi(updatedWebUi),
+					resource.TestCheckResourceAttr("qumulo_web_ui.settings", "on_destroy", ResetToDefaults.String()),
 				),
 			},
 		},
@@ -29,6 +60,15 @@ func TestAccSetWebUi(t *testing.T) {
 
 var loginBanner = "SampleBanner"
 
+var defaultLoginBanner = ""
+
+var defaultWebUi = WebUiBody{
+	InactivityTimeout: WebUiTimeout{
+		Nanoseconds: "900000000000",
+	},
+	LoginBanner: &defaultLoginBanner,
+}
+
 var testingWebUi = WebUiBody{
 	InactivityTimeout: WebUiTimeout{
 		Nanoseconds: "900000000000",
@@ -36,15 +76,20 @@ var testingWebUi = WebUiBody{
 	LoginBanner: &loginBanner,
 }
 
-func testAccWebUiConfig(req WebUiBody) string {
+var updatedWebUi = WebUiBody{
+	InactivityTimeout: WebUiTimeout{
+		Nanoseconds: "5400000000000",
+	},
+	LoginBanner: &loginBanner,
+}
+
+func testAccWebUiConfig(req WebUiBody, inactivityTimeout string) string {
 	return fmt.Sprintf(`
 	resource "qumulo_web_ui" "settings" {
-		inactivity_timeout {
-			nanoseconds = %v
-		}
+		inactivity_timeout = %q
 		login_banner = %q
 	}
-  `, req.InactivityTimeout.Nanoseconds, *testingWebUi.LoginBanner)
+  `, inactivityTimeout, *req.LoginBanner)
 }
 
 func testAccCheckWebUi(uiConfig WebUiBody) resource.TestCheckFunc {
@@ -63,10 +108,11 @@ func testAccCheckWebUi(uiConfig WebUiBody) resource.TestCheckFunc {
 	}
 }
 
-func testAccCompareWebUiSettings(uiConfig WebUiBody) resource.TestCheckFunc {
+func testAccCompareWebUiSettings(uiConfig WebUiBody, inactivityTimeout string) resource.TestCheckFunc {
 	return resource.ComposeTestCheckFunc(
-		resource.TestCheckResourceAttr("qumulo_web_ui.settings", "inactivity_timeout.0.nanoseconds",
-			fmt.Sprintf("%v", uiConfig.InactivityTimeout.Nanoseconds)),
+		resource.TestCheckResourceAttr("qumulo_web_ui.settings", "inactivity_timeout", inactivityTimeout),
+		resource.TestCheckResourceAttr("qumulo_web_ui.settings", "inactivity_timeout_nanoseconds",
+			uiConfig.InactivityTimeout.Nanoseconds),
 		resource.TestCheckResourceAttr("qumulo_web_ui.settings", "login_banner",
 			fmt.Sprintf("%v", *uiConfig.LoginBanner)),
 	)
@@ -0,0 +1,52 @@
+// Code generated by tools/resourcegen from qumulo_api.json. DO NOT EDIT.
+
+package qumulo
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// A role, which grants its members privileges on the cluster
+type Role struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Privileges  []string `json:"privileges"`
+}
+
+func roleSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"name": {
+			Type:     schema.TypeString,
+			Required: true,
+			ForceNew: true,
+		},
+		"description": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		"privileges": {
+			Type:     schema.TypeList,
+			Required: true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+	}
+}
+
+func expandRole(d *schema.ResourceData) Role {
+	role := Role{}
+	role.Name = d.Get("name").(string)
+	role.Description = d.Get("description").(string)
+	role.Privileges = InterfaceSliceToStringSlice(d.Get("privileges").([]interface{}))
+	return role
+}
+
+func flattenRole(d *schema.ResourceData, role *Role) diag.Diagnostics {
+	var errs ErrorCollection
+	errs.addMaybeError(d.Set("name", role.Name))
+	errs.addMaybeError(d.Set("description", role.Description))
+	errs.addMaybeError(d.Set("privileges", role.Privileges))
+	return errs.diags
+}
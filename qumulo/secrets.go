@@ -0,0 +1,111 @@
+package qumulo
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Secrets are kept in state as "sha256$<salt>$<hash>" rather than in plaintext
+const SecretHashAlgorithm = "sha256"
+const SecretSaltBytes = 16
+
+func secretSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:             schema.TypeString,
+		Optional:         true,
+		Sensitive:        true,
+		DiffSuppressFunc: suppressSecretHashDiff,
+	}
+}
+
+// Write-only secrets are never stored in state, so they can't produce a diff by themselves; they are
+// sent to the cluster on create and whenever the accompanying version attribute changes
+func writeOnlySecretSchema(conflictsWith string) *schema.Schema {
+	return &schema.Schema{
+		Type:          schema.TypeString,
+		Optional:      true,
+		Sensitive:     true,
+		ConflictsWith: []string{conflictsWith},
+		StateFunc: func(interface{}) string {
+			return ""
+		},
+	}
+}
+
+func secretVersionSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeInt,
+		Optional: true,
+		Default:  0,
+	}
+}
+
+func hashSecret(secret string) (string, error) {
+	salt := make([]byte, SecretSaltBytes)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+
+	return hashSecretWithSalt(secret, hex.EncodeToString(salt)), nil
+}
+
+func hashSecretWithSalt(secret, salt string) string {
+	sum := sha256.Sum256([]byte(salt + secret))
+	return strings.Join([]string{SecretHashAlgorithm, salt, hex.EncodeToString(sum[:])}, "$")
+}
+
+func isSecretHash(v string) bool {
+	parts := strings.Split(v, "$")
+	return len(parts) == 3 && parts[0] == SecretHashAlgorithm
+}
+
+func secretMatchesHash(secret, hashed string) bool {
+	if !isSecretHash(hashed) {
+		return false
+	}
+	salt := strings.Split(hashed, "$")[1]
+
+	return subtle.ConstantTimeCompare([]byte(hashSecretWithSalt(secret, salt)), []byte(hashed)) == 1
+}
+
+// The state holds a salted hash and the config holds the plaintext, so they are compared by hashing
+// the config with the salt from state
+func suppressSecretHashDiff(k, old, new string, d *schema.ResourceData) bool {
+	return old != "" && secretMatchesHash(new, old)
+}
+
+// Only the hash of a secret is kept in state, so the plaintext has to be read from the config. Returns
+// an empty string outside of plan and apply, e.g. on delete.
+func getSecret(d *schema.ResourceData, key string) string {
+	config := d.GetRawConfig()
+	if config.IsNull() || !config.IsKnown() {
+		return ""
+	}
+
+	v := config.GetAttr(key)
+	if v.IsNull() || !v.IsKnown() {
+		return ""
+	}
+
+	return v.AsString()
+}
+
+// Stores the salted hash of the secret that was just applied, or of a plaintext value left in state by
+// an earlier version of the provider
+func setSecretHash(d *schema.ResourceData, key, secret string) error {
+	if secret == "" || isSecretHash(secret) {
+		return nil
+	}
+
+	hashed, err := hashSecret(secret)
+	if err != nil {
+		return err
+	}
+
+	return d.Set(key, hashed)
+}
@@ -0,0 +1,79 @@
+package qumulo
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestHashSecret(t *testing.T) {
+	hashed, err := hashSecret("Test1234")
+	if err != nil {
+		t.Fatalf("unexpected error hashing secret: %v", err)
+	}
+
+	if strings.Contains(hashed, "Test1234") || !isSecretHash(hashed) {
+		t.Errorf("expected a salted hash, got %q", hashed)
+	}
+	if !secretMatchesHash("Test1234", hashed) {
+		t.Errorf("expected the secret to match its hash")
+	}
+	if secretMatchesHash("Test12345", hashed) {
+		t.Errorf("expected a different secret not to match the hash")
+	}
+
+	rehashed, _ := hashSecret("Test1234")
+	if rehashed == hashed {
+		t.Errorf("expected hashes of the same secret to use different salts")
+	}
+}
+
+func TestSuppressSecretHashDiff(t *testing.T) {
+	hashed, _ := hashSecret("Test1234")
+
+	if !suppressSecretHashDiff("password", hashed, "Test1234", nil) {
+		t.Errorf("expected an unchanged secret to be suppressed")
+	}
+	if suppressSecretHashDiff("password", hashed, "Rotated1234", nil) {
+		t.Errorf("expected a changed secret to produce a diff")
+	}
+	if suppressSecretHashDiff("password", "", "Test1234", nil) {
+		t.Errorf("expected a new secret to produce a diff")
+	}
+	// State written by earlier versions of the provider holds the plaintext
+	if suppressSecretHashDiff("password", "Test1234", "Test1234", nil) {
+		t.Errorf("expected a plaintext secret in state to produce a diff")
+	}
+}
+
+func TestSetSecretHash(t *testing.T) {
+	d := resourceUser().TestResourceData()
+
+	if err := d.Set("password", "Test1234"); err != nil {
+		t.Fatalf("unexpected error setting password: %v", err)
+	}
+	if err := setSecretHash(d, "password", d.Get("password").(string)); err != nil {
+		t.Fatalf("unexpected error hashing password: %v", err)
+	}
+
+	hashed := d.Get("password").(string)
+	if !secretMatchesHash("Test1234", hashed) {
+		t.Errorf("expected plaintext in state to be replaced by its hash, got %q", hashed)
+	}
+
+	if err := setSecretHash(d, "password", hashed); err != nil {
+		t.Fatalf("unexpected error rehashing password: %v", err)
+	}
+	if d.Get("password").(string) != hashed {
+		t.Errorf("expected an existing hash to be left alone")
+	}
+}
+
+func testAccCheckSecretHash(secret string) func(string) error {
+	return func(value string) error {
+		if !secretMatchesHash(secret, value) {
+			return fmt.Errorf("expected a salted hash of the secret in state, got %q", value)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,172 @@
+package qumulo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const NodeStateEndpoint = "/v1/node/state"
+
+type NodeStateResponse struct {
+	NodeId    int    `json:"node_id"`
+	State     string `json:"state"`
+	ClusterId string `json:"cluster_id"`
+}
+
+// Wraps a resource which manages cluster wide settings. There is exactly one instance of those settings
+// per cluster, so the resource is identified by the cluster UUID and may only be declared once.
+func singletonResource(resourceType string, r *schema.Resource) *schema.Resource {
+	// Version 0 states were identified by the Unix time at which they were created, and didn't have the
+	// attributes shared by the settings resources. Resources which changed their schema since then upgrade
+	// from version 1, whose state type was the one of version 0 apart from those. Resources which added
+	// attributes of their own give the upgrader from version 0 themselves.
+	if len(r.StateUpgraders) == 0 || r.StateUpgraders[0].Version != 0 {
+		v1Type := r.CoreConfigSchema().ImpliedType()
+		if len(r.StateUpgraders) > 0 {
+			v1Type = r.StateUpgraders[0].Type
+		} else {
+			r.SchemaVersion = 1
+		}
+		r.StateUpgraders = append([]schema.StateUpgrader{
+			{
+				Version: 0,
+				Type:    withoutAttributes(v1Type, "etag", "on_destroy", "previous_settings"),
+				Upgrade: upgradeWithDefaults(r, upgradeSingletonIdV0),
+			},
+		}, r.StateUpgraders...)
+	}
+
+	r.Importer = &schema.ResourceImporter{
+		StateContext: importSingletonState,
+	}
+
+	if r.CustomizeDiff != nil {
+		r.CustomizeDiff = customdiff.All(guardSingleton(resourceType, r), r.CustomizeDiff)
+	} else {
+		r.CustomizeDiff = guardSingleton(resourceType, r)
+	}
+
+	return r
+}
+
+func readClusterUuid(ctx context.Context, c *Client) (string, error) {
+	c.clusterUuidMutex.Lock()
+	defer c.clusterUuidMutex.Unlock()
+
+	if c.clusterUuid != "" {
+		return c.clusterUuid, nil
+	}
+
+	nodeState, err := DoRequest[NodeStateResponse, NodeStateResponse](ctx, c, GET, NodeStateEndpoint, nil)
+	if err != nil {
+		return "", fmt.Errorf("unable to read the cluster UUID: %w", err)
+	}
+	c.clusterUuid = nodeState.ClusterId
+
+	return c.clusterUuid, nil
+}
+
+func setSingletonId(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	c := m.(*Client)
+
+	clusterUuid, err := readClusterUuid(ctx, c)
+	if err != nil {
+		return err
+	}
+	d.SetId(clusterUuid)
+
+	return nil
+}
+
+func importSingletonState(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	c := m.(*Client)
+
+	clusterUuid, err := readClusterUuid(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+	if d.Id() != clusterUuid {
+		return nil, fmt.Errorf("singleton resources are imported by cluster UUID: expected %q, got %q", clusterUuid, d.Id())
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func upgradeSingletonIdV0(ctx context.Context, rawState map[string]interface{}, m interface{}) (map[string]interface{}, error) {
+	c, ok := m.(*Client)
+	if !ok || c == nil {
+		return nil, fmt.Errorf("unable to upgrade singleton resource state: provider is not configured")
+	}
+
+	clusterUuid, err := readClusterUuid(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+
+	tflog.Info(ctx, "Upgrading singleton resource ID to the cluster UUID", map[string]interface{}{
+		"old_id": rawState["id"],
+		"new_id": clusterUuid,
+	})
+	rawState["id"] = clusterUuid
+
+	return rawState, nil
+}
+
+// The plan which claimed a singleton resource type on a cluster
+type singletonClaim struct {
+	ctx    context.Context
+	config cty.Value
+	// Set when the claiming instance is being replaced, which Terraform plans a second time as a create
+	replacing bool
+}
+
+// Fails the plan when a second resource of the same singleton type is planned against this cluster,
+// since both would fight over the same settings. Only the resources planned by this provider instance
+// are seen: resources using another alias of the provider, or another workspace, aren't.
+func guardSingleton(resourceType string, r *schema.Resource) schema.CustomizeDiffFunc {
+	var forceNew []string
+	for k, s := range r.Schema {
+		if s.ForceNew {
+			forceNew = append(forceNew, k)
+		}
+	}
+
+	return func(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+		c := m.(*Client)
+
+		clusterUuid, err := readClusterUuid(ctx, c)
+		if err != nil {
+			return err
+		}
+		key := resourceType + "-" + clusterUuid
+		conflict := fmt.Errorf("%s manages settings which exist once per cluster, but more than one %s resource "+
+			"targets cluster %s", resourceType, resourceType, clusterUuid)
+
+		// Every resource instance is planned by its own request, including each instance of a resource
+		// with count or for_each, so a claim is only shared by later calls for the same request, and by
+		// the second plan of an instance being replaced. That plan has no prior state but the same config.
+		config := d.GetRawConfig()
+		replacing := d.Id() != "" && len(forceNew) > 0 && d.HasChanges(forceNew...)
+
+		c.singletonsMutex.Lock()
+		defer c.singletonsMutex.Unlock()
+
+		if c.singletons == nil {
+			c.singletons = map[string]*singletonClaim{}
+		}
+		if claim, ok := c.singletons[key]; ok && claim.ctx != ctx {
+			if !(claim.replacing && d.Id() == "" && claim.config.RawEquals(config)) {
+				return conflict
+			}
+		}
+		c.singletons[key] = &singletonClaim{ctx: ctx, config: config, replacing: replacing}
+
+		return nil
+	}
+}
@@ -0,0 +1,80 @@
+package qumulo
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+const testClusterUuid = "3f5b2c1e-8a0d-4c6e-9b7f-1d2e3f4a5b6c"
+
+func TestUpgradeSingletonIdV0(t *testing.T) {
+	c := &Client{clusterUuid: testClusterUuid}
+
+	v0State := map[string]interface{}{
+		"id":      "1658448000",
+		"enabled": true,
+	}
+
+	v1State, err := upgradeSingletonIdV0(context.Background(), v0State, c)
+	if err != nil {
+		t.Fatalf("unexpected error upgrading state: %v", err)
+	}
+	if v1State["id"] != testClusterUuid {
+		t.Errorf("expected ID to be upgraded to the cluster UUID, got %v", v1State["id"])
+	}
+	if v1State["enabled"] != true {
+		t.Errorf("expected other attributes to be preserved, got %v", v1State)
+	}
+
+	if _, err := upgradeSingletonIdV0(context.Background(), v0State, nil); err == nil {
+		t.Errorf("expected an error upgrading state without a configured provider")
+	}
+}
+
+func TestImportSingletonState(t *testing.T) {
+	c := &Client{clusterUuid: testClusterUuid}
+	r := singletonResource("qumulo_syslog", resourceSyslog())
+
+	d := r.TestResourceData()
+	d.SetId(testClusterUuid)
+	if _, err := r.Importer.StateContext(context.Background(), d, c); err != nil {
+		t.Errorf("unexpected error importing by cluster UUID: %v", err)
+	}
+
+	d = r.TestResourceData()
+	d.SetId("1")
+	if _, err := r.Importer.StateContext(context.Background(), d, c); err == nil {
+		t.Errorf("expected an error importing by an ID other than the cluster UUID")
+	}
+}
+
+func TestSingletonResourcesInternalValidate(t *testing.T) {
+	// Catches state upgraders which don't line up with the schema version
+	if err := Provider().InternalValidate(); err != nil {
+		t.Fatalf("provider failed internal validation: %v", err)
+	}
+}
+
+func TestAccSingletonWithCount(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				// Both instances have the same config, but would still fight over the same settings
+				Config: `
+resource "qumulo_syslog" "test_syslog_settings" {
+	count = 2
+	enabled = false
+	server_address = ""
+	server_port = 0
+}
+  `,
+				ExpectError: regexp.MustCompile(`more\s+than\s+one\s+qumulo_syslog\s+resource\s+targets\s+cluster`),
+			},
+		},
+	})
+}
@@ -0,0 +1,99 @@
+package qumulo
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+type sizeUnit struct {
+	Name  string
+	Bytes int64
+}
+
+// Ordered from the largest, with binary units before decimal ones of similar size
+var sizeUnits = []sizeUnit{
+	{"EiB", 1 << 60}, {"EB", 1e18},
+	{"PiB", 1 << 50}, {"PB", 1e15},
+	{"TiB", 1 << 40}, {"TB", 1e12},
+	{"GiB", 1 << 30}, {"GB", 1e9},
+	{"MiB", 1 << 20}, {"MB", 1e6},
+	{"KiB", 1 << 10}, {"KB", 1e3},
+	{"B", 1},
+}
+
+var sizeNumberRegexp = regexp.MustCompile(`^[0-9]+(\.[0-9]+)?$`)
+
+// Parses a number of bytes with an optional unit, such as "10TiB", "500GB", "1.5 TiB" or "1000000000".
+// Units are case insensitive, and decimal numbers have to come to a whole number of bytes.
+func parseSize(v string) (int64, error) {
+	s := strings.TrimSpace(v)
+	multiplier := int64(1)
+	for _, unit := range sizeUnits {
+		if len(s) > len(unit.Name) && strings.EqualFold(s[len(s)-len(unit.Name):], unit.Name) {
+			s = strings.TrimSpace(s[:len(s)-len(unit.Name)])
+			multiplier = unit.Bytes
+			break
+		}
+	}
+
+	number, ok := new(big.Rat).SetString(s)
+	if !ok || !sizeNumberRegexp.MatchString(s) {
+		return 0, fmt.Errorf("expected a size such as \"10TiB\" or \"500GB\", got %q", v)
+	}
+	bytes := number.Mul(number, new(big.Rat).SetInt64(multiplier))
+	if !bytes.IsInt() {
+		return 0, fmt.Errorf("expected %q to be a whole number of bytes", v)
+	}
+	if bytes.Num().Cmp(big.NewInt(math.MaxInt64)) > 0 {
+		return 0, fmt.Errorf("expected %q to be at most %d bytes", v, int64(math.MaxInt64))
+	}
+
+	return bytes.Num().Int64(), nil
+}
+
+// Formats a number of bytes with the largest unit it is a whole multiple of, such as "10TiB" or "1GB".
+// Other numbers are formatted as bytes without a unit.
+func formatSize(bytes int64) string {
+	if bytes == 0 {
+		return "0"
+	}
+	for _, unit := range sizeUnits {
+		if unit.Bytes > 1 && bytes%unit.Bytes == 0 {
+			return strconv.FormatInt(bytes/unit.Bytes, 10) + unit.Name
+		}
+	}
+	return strconv.FormatInt(bytes, 10)
+}
+
+func validateSize(i interface{}, k string) ([]string, []error) {
+	v, ok := i.(string)
+	if !ok {
+		return nil, []error{fmt.Errorf("expected type of %s to be string", k)}
+	}
+
+	if _, err := parseSize(v); err != nil {
+		return nil, []error{fmt.Errorf("invalid %s: %w", k, err)}
+	}
+
+	return nil, nil
+}
+
+// Sizes are equivalent when they are as many bytes, such as "1GiB" and "1073741824"
+func suppressEquivalentSize(k, old, new string, d *schema.ResourceData) bool {
+	oldBytes, err := parseSize(old)
+	if err != nil {
+		return false
+	}
+	newBytes, err := parseSize(new)
+	if err != nil {
+		return false
+	}
+
+	return oldBytes == newBytes
+}
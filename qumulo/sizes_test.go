@@ -0,0 +1,69 @@
+package qumulo
+
+import (
+	"testing"
+)
+
+func TestParseSize(t *testing.T) {
+	for _, tc := range []struct {
+		v        string
+		expected int64
+	}{
+		{"1000000000", 1000000000},
+		{"0", 0},
+		{"500GB", 500000000000},
+		{"10TiB", 10995116277760},
+		{"10 tib", 10995116277760},
+		{"1.5KiB", 1536},
+		{"2B", 2},
+		{"7EiB", 8070450532247928832},
+	} {
+		bytes, err := parseSize(tc.v)
+		if err != nil {
+			t.Errorf("unexpected error parsing %q: %v", tc.v, err)
+		} else if bytes != tc.expected {
+			t.Errorf("expected %q to be %d bytes, got %d", tc.v, tc.expected, bytes)
+		}
+	}
+
+	for _, v := range []string{"", "GB", "ten GB", "-1GB", "1.5B", "1e9", "1/2", "0x10", "10XB", "8EiB"} {
+		if _, err := parseSize(v); err == nil {
+			t.Errorf("expected an error parsing %q", v)
+		}
+	}
+}
+
+func TestFormatSize(t *testing.T) {
+	for _, tc := range []struct {
+		bytes    int64
+		expected string
+	}{
+		{0, "0"},
+		{1000000000, "1GB"},
+		{2147483648, "2GiB"},
+		{10995116277760, "10TiB"},
+		{1536, "1536"},
+		{1500, "1500"},
+		{3000, "3KB"},
+		{1234567, "1234567"},
+	} {
+		if got := formatSize(tc.bytes); got != tc.expected {
+			t.Errorf("expected %d bytes to be formatted as %q, got %q", tc.bytes, tc.expected, got)
+		}
+		if bytes, err := parseSize(formatSize(tc.bytes)); err != nil || bytes != tc.bytes {
+			t.Errorf("expected %q to be parsed as %d bytes, got %d: %v", formatSize(tc.bytes), tc.bytes, bytes, err)
+		}
+	}
+}
+
+func TestSuppressEquivalentSize(t *testing.T) {
+	if !suppressEquivalentSize("limit", "1GiB", "1073741824", nil) {
+		t.Errorf("expected 1GiB and 1073741824 to be equivalent")
+	}
+	if suppressEquivalentSize("limit", "1GiB", "1GB", nil) {
+		t.Errorf("expected 1GiB and 1GB to differ")
+	}
+	if suppressEquivalentSize("limit", "", "1GB", nil) {
+		t.Errorf("expected an unset size to differ")
+	}
+}
@@ -0,0 +1,372 @@
+// Code generated by tools/resourcegen from qumulo_api.json. DO NOT EDIT.
+
+package qumulo
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var SmbPermissionTypes = []string{"ALLOWED", "DENIED"}
+
+var SmbRights = []string{"READ", "WRITE", "CHANGE_PERMISSIONS"}
+
+// An SMB share of a directory
+type SmbShare struct {
+	Id                         string                 `json:"id"`
+	ShareName                  string                 `json:"share_name"`
+	FsPath                     string                 `json:"fs_path"`
+	Description                string                 `json:"description"`
+	Permissions                []SmbPermission        `json:"permissions"`
+	NetworkPermissions         []SmbNetworkPermission `json:"network_permissions"`
+	AccessBasedEnumEnabled     bool                   `json:"access_based_enumeration_enabled"`
+	DefaultFileCreateMode      string                 `json:"default_file_create_mode,omitempty"`
+	DefaultDirectoryCreateMode string                 `json:"default_directory_create_mode,omitempty"`
+	BytesPerSector             string                 `json:"bytes_per_sector,omitempty"`
+	RequireEncryption          bool                   `json:"require_encryption"`
+}
+
+func smbShareSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"share_name": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		"fs_path": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		"description": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		"permissions": {
+			Type:     schema.TypeList,
+			Required: true,
+			MinItems: 1,
+			Elem: &schema.Resource{
+				Schema: smbPermissionSchema(),
+			},
+		},
+		"network_permissions": {
+			Type:     schema.TypeList,
+			Required: true,
+			MinItems: 1,
+			Elem: &schema.Resource{
+				Schema: smbNetworkPermissionSchema(),
+			},
+		},
+		"access_based_enumeration_enabled": {
+			Type:     schema.TypeBool,
+			Required: true,
+		},
+		"default_file_create_mode": {
+			Type:     schema.TypeString,
+			Optional: true,
+			Computed: true,
+		},
+		"default_directory_create_mode": {
+			Type:     schema.TypeString,
+			Optional: true,
+			Computed: true,
+		},
+		"bytes_per_sector": {
+			Type:     schema.TypeString,
+			Optional: true,
+			Computed: true,
+		},
+		"require_encryption": {
+			Type:     schema.TypeBool,
+			Optional: true,
+		},
+	}
+}
+
+func expandSmbShare(d *schema.ResourceData) SmbShare {
+	smbShare := SmbShare{}
+	smbShare.ShareName = d.Get("share_name").(string)
+	smbShare.FsPath = d.Get("fs_path").(string)
+	smbShare.Description = d.Get("description").(string)
+	smbShare.Permissions = expandSmbPermissionList(d.Get("permissions").([]interface{}))
+	smbShare.NetworkPermissions = expandSmbNetworkPermissionList(d.Get("network_permissions").([]interface{}))
+	smbShare.AccessBasedEnumEnabled = d.Get("access_based_enumeration_enabled").(bool)
+	smbShare.DefaultFileCreateMode = d.Get("default_file_create_mode").(string)
+	smbShare.DefaultDirectoryCreateMode = d.Get("default_directory_create_mode").(string)
+	smbShare.BytesPerSector = d.Get("bytes_per_sector").(string)
+	smbShare.RequireEncryption = d.Get("require_encryption").(bool)
+	return smbShare
+}
+
+func flattenSmbShare(d *schema.ResourceData, smbShare *SmbShare) diag.Diagnostics {
+	var errs ErrorCollection
+	errs.addMaybeError(d.Set("share_name", smbShare.ShareName))
+	errs.addMaybeError(d.Set("fs_path", smbShare.FsPath))
+	errs.addMaybeError(d.Set("description", smbShare.Description))
+	errs.addMaybeError(d.Set("permissions", flattenSmbPermissionList(smbShare.Permissions)))
+	errs.addMaybeError(d.Set("network_permissions", flattenSmbNetworkPermissionList(smbShare.NetworkPermissions)))
+	errs.addMaybeError(d.Set("access_based_enumeration_enabled", smbShare.AccessBasedEnumEnabled))
+	errs.addMaybeError(d.Set("default_file_create_mode", smbShare.DefaultFileCreateMode))
+	errs.addMaybeError(d.Set("default_directory_create_mode", smbShare.DefaultDirectoryCreateMode))
+	errs.addMaybeError(d.Set("bytes_per_sector", smbShare.BytesPerSector))
+	errs.addMaybeError(d.Set("require_encryption", smbShare.RequireEncryption))
+	return errs.diags
+}
+
+// The rights a trustee is allowed or denied on an SMB share
+type SmbPermission struct {
+	Type    string     `json:"type"`
+	Trustee SmbTrustee `json:"trustee"`
+	Rights  []string   `json:"rights"`
+}
+
+func smbPermissionSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"type": {
+			Type:             schema.TypeString,
+			Required:         true,
+			ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(SmbPermissionTypes, false)),
+		},
+		"trustee": {
+			Type:     schema.TypeList,
+			Required: true,
+			MaxItems: 1,
+			MinItems: 1,
+			Elem: &schema.Resource{
+				Schema: smbTrusteeSchema(),
+			},
+		},
+		"rights": {
+			Type:     schema.TypeList,
+			Required: true,
+			Elem: &schema.Schema{
+				Type:             schema.TypeString,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(SmbRights, false)),
+			},
+		},
+	}
+}
+
+func expandSmbPermissionList(tfList []interface{}) []SmbPermission {
+	var smbPermissionList []SmbPermission
+	for _, tfElement := range tfList {
+		if tfMap, ok := tfElement.(map[string]interface{}); ok {
+			smbPermissionList = append(smbPermissionList, expandSmbPermission(tfMap))
+		} else {
+			smbPermissionList = append(smbPermissionList, SmbPermission{})
+		}
+	}
+	return smbPermissionList
+}
+
+func expandSmbPermission(tfMap map[string]interface{}) SmbPermission {
+	smbPermission := SmbPermission{}
+	if v, ok := tfMap["type"].(string); ok {
+		smbPermission.Type = v
+	}
+	if v, ok := tfMap["trustee"].([]interface{}); ok {
+		if elements := expandSmbTrusteeList(v); len(elements) > 0 {
+			smbPermission.Trustee = elements[0]
+		}
+	}
+	if v, ok := tfMap["rights"].([]interface{}); ok {
+		smbPermission.Rights = InterfaceSliceToStringSlice(v)
+	}
+	return smbPermission
+}
+
+func flattenSmbPermissionList(smbPermissionList []SmbPermission) []interface{} {
+	var tfList []interface{}
+	for _, smbPermission := range smbPermissionList {
+		tfList = append(tfList, flattenSmbPermission(smbPermission))
+	}
+	return tfList
+}
+
+func flattenSmbPermission(smbPermission SmbPermission) map[string]interface{} {
+	tfMap := map[string]interface{}{}
+	tfMap["type"] = smbPermission.Type
+	tfMap["trustee"] = flattenSmbTrusteeList([]SmbTrustee{smbPermission.Trustee})
+	if len(smbPermission.Rights) != 0 {
+		tfMap["rights"] = smbPermission.Rights
+	}
+	return tfMap
+}
+
+// An identity, given by any of its IDs or its name
+type SmbTrustee struct {
+	Domain string `json:"domain"`
+	AuthId string `json:"auth_id,omitempty"`
+	Uid    int    `json:"uid,omitempty"`
+	Gid    int    `json:"gid,omitempty"`
+	Sid    string `json:"sid,omitempty"`
+	Name   string `json:"name,omitempty"`
+}
+
+func smbTrusteeSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"domain": {
+			Type:     schema.TypeString,
+			Optional: true,
+			Computed: true,
+		},
+		"auth_id": {
+			Type:     schema.TypeString,
+			Optional: true,
+			Computed: true,
+		},
+		"uid": {
+			Type:     schema.TypeInt,
+			Optional: true,
+			Computed: true,
+		},
+		"gid": {
+			Type:     schema.TypeInt,
+			Optional: true,
+			Computed: true,
+		},
+		"sid": {
+			Type:     schema.TypeString,
+			Optional: true,
+			Computed: true,
+		},
+		"name": {
+			Type:     schema.TypeString,
+			Optional: true,
+			Computed: true,
+		},
+	}
+}
+
+func expandSmbTrusteeList(tfList []interface{}) []SmbTrustee {
+	var smbTrusteeList []SmbTrustee
+	for _, tfElement := range tfList {
+		if tfMap, ok := tfElement.(map[string]interface{}); ok {
+			smbTrusteeList = append(smbTrusteeList, expandSmbTrustee(tfMap))
+		} else {
+			smbTrusteeList = append(smbTrusteeList, SmbTrustee{})
+		}
+	}
+	return smbTrusteeList
+}
+
+func expandSmbTrustee(tfMap map[string]interface{}) SmbTrustee {
+	smbTrustee := SmbTrustee{}
+	if v, ok := tfMap["domain"].(string); ok {
+		smbTrustee.Domain = v
+	}
+	if v, ok := tfMap["auth_id"].(string); ok {
+		smbTrustee.AuthId = v
+	}
+	if v, ok := tfMap["uid"].(int); ok {
+		smbTrustee.Uid = v
+	}
+	if v, ok := tfMap["gid"].(int); ok {
+		smbTrustee.Gid = v
+	}
+	if v, ok := tfMap["sid"].(string); ok {
+		smbTrustee.Sid = v
+	}
+	if v, ok := tfMap["name"].(string); ok {
+		smbTrustee.Name = v
+	}
+	return smbTrustee
+}
+
+func flattenSmbTrusteeList(smbTrusteeList []SmbTrustee) []interface{} {
+	var tfList []interface{}
+	for _, smbTrustee := range smbTrusteeList {
+		tfList = append(tfList, flattenSmbTrustee(smbTrustee))
+	}
+	return tfList
+}
+
+func flattenSmbTrustee(smbTrustee SmbTrustee) map[string]interface{} {
+	tfMap := map[string]interface{}{}
+	tfMap["domain"] = smbTrustee.Domain
+	tfMap["auth_id"] = smbTrustee.AuthId
+	tfMap["uid"] = smbTrustee.Uid
+	tfMap["gid"] = smbTrustee.Gid
+	tfMap["sid"] = smbTrustee.Sid
+	tfMap["name"] = smbTrustee.Name
+	return tfMap
+}
+
+// The rights the hosts in some address ranges are allowed or denied on an SMB share
+type SmbNetworkPermission struct {
+	Type          string   `json:"type"`
+	AddressRanges []string `json:"address_ranges"`
+	Rights        []string `json:"rights"`
+}
+
+func smbNetworkPermissionSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"type": {
+			Type:             schema.TypeString,
+			Required:         true,
+			ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(SmbPermissionTypes, false)),
+		},
+		"address_ranges": {
+			Type:     schema.TypeList,
+			Optional: true,
+			Elem: &schema.Schema{
+				Type:             schema.TypeString,
+				ValidateDiagFunc: validation.ToDiagFunc(validateIpRange),
+			},
+		},
+		"rights": {
+			Type:     schema.TypeList,
+			Required: true,
+			Elem: &schema.Schema{
+				Type:             schema.TypeString,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(SmbRights, false)),
+			},
+		},
+	}
+}
+
+func expandSmbNetworkPermissionList(tfList []interface{}) []SmbNetworkPermission {
+	var smbNetworkPermissionList []SmbNetworkPermission
+	for _, tfElement := range tfList {
+		if tfMap, ok := tfElement.(map[string]interface{}); ok {
+			smbNetworkPermissionList = append(smbNetworkPermissionList, expandSmbNetworkPermission(tfMap))
+		} else {
+			smbNetworkPermissionList = append(smbNetworkPermissionList, SmbNetworkPermission{})
+		}
+	}
+	return smbNetworkPermissionList
+}
+
+func expandSmbNetworkPermission(tfMap map[string]interface{}) SmbNetworkPermission {
+	smbNetworkPermission := SmbNetworkPermission{}
+	if v, ok := tfMap["type"].(string); ok {
+		smbNetworkPermission.Type = v
+	}
+	if v, ok := tfMap["address_ranges"].([]interface{}); ok {
+		smbNetworkPermission.AddressRanges = InterfaceSliceToStringSlice(v)
+	}
+	if v, ok := tfMap["rights"].([]interface{}); ok {
+		smbNetworkPermission.Rights = InterfaceSliceToStringSlice(v)
+	}
+	return smbNetworkPermission
+}
+
+func flattenSmbNetworkPermissionList(smbNetworkPermissionList []SmbNetworkPermission) []interface{} {
+	var tfList []interface{}
+	for _, smbNetworkPermission := range smbNetworkPermissionList {
+		tfList = append(tfList, flattenSmbNetworkPermission(smbNetworkPermission))
+	}
+	return tfList
+}
+
+func flattenSmbNetworkPermission(smbNetworkPermission SmbNetworkPermission) map[string]interface{} {
+	tfMap := map[string]interface{}{}
+	tfMap["type"] = smbNetworkPermission.Type
+	if len(smbNetworkPermission.AddressRanges) != 0 {
+		tfMap["address_ranges"] = smbNetworkPermission.AddressRanges
+	}
+	if len(smbNetworkPermission.Rights) != 0 {
+		tfMap["rights"] = smbNetworkPermission.Rights
+	}
+	return tfMap
+}
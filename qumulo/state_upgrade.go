@@ -0,0 +1,78 @@
+package qumulo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// The state type of an earlier schema version of a resource, which had other types for the given
+// attributes. Attributes given cty.NilType didn't exist yet.
+func earlierStateType(r *schema.Resource, attributeTypes map[string]cty.Type) cty.Type {
+	types := map[string]cty.Type{}
+	for k, t := range r.CoreConfigSchema().ImpliedType().AttributeTypes() {
+		types[k] = t
+	}
+	for k, t := range attributeTypes {
+		if t == cty.NilType {
+			delete(types, k)
+			continue
+		}
+		types[k] = t
+	}
+	return cty.Object(types)
+}
+
+// The state type without the given attributes, which didn't exist yet
+func withoutAttributes(t cty.Type, names ...string) cty.Type {
+	types := map[string]cty.Type{}
+	for k, t := range t.AttributeTypes() {
+		types[k] = t
+	}
+	for _, k := range names {
+		delete(types, k)
+	}
+	return cty.Object(types)
+}
+
+// Wraps the upgrader from an earlier schema version so that attributes added since that version are
+// given their defaults. Left null, Terraform would plan to change them to the default.
+func upgradeWithDefaults(r *schema.Resource, upgrade schema.StateUpgradeFunc) schema.StateUpgradeFunc {
+	return func(ctx context.Context, rawState map[string]interface{}, m interface{}) (map[string]interface{}, error) {
+		rawState, err := upgrade(ctx, rawState, m)
+		if err != nil {
+			return nil, err
+		}
+		for k, s := range r.Schema {
+			if _, ok := rawState[k]; !ok && s.Default != nil {
+				rawState[k] = s.Default
+			}
+		}
+		return rawState, nil
+	}
+}
+
+// Converts an attribute which was a string of digits to a number. Empty strings, which the SDK stored
+// for unset strings, become null.
+func upgradeStringToInt(rawState map[string]interface{}, key string) error {
+	v, ok := rawState[key].(string)
+	if !ok {
+		return nil
+	}
+	if v == "" {
+		rawState[key] = nil
+		return nil
+	}
+
+	if _, err := strconv.ParseInt(v, 10, 64); err != nil {
+		return fmt.Errorf("unable to upgrade %s: %q is not a whole number", key, v)
+	}
+	// Numbers are kept exact when the state is encoded again
+	rawState[key] = json.Number(v)
+
+	return nil
+}
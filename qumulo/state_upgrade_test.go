@@ -0,0 +1,165 @@
+package qumulo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	ctyjson "github.com/hashicorp/go-cty/cty/json"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// A state written by an earlier schema version of a resource, with the state it is expected to be
+// upgraded to
+type stateUpgradeFixture struct {
+	ResourceType  string          `json:"resource_type"`
+	SchemaVersion int64           `json:"schema_version"`
+	State         json.RawMessage `json:"state"`
+	Upgraded      json.RawMessage `json:"upgraded"`
+}
+
+func readStateUpgradeFixtures(t *testing.T) map[string]stateUpgradeFixture {
+	paths, err := filepath.Glob(filepath.Join("testdata", "state_upgrades", "*.json"))
+	if err != nil {
+		t.Fatalf("unexpected error listing the fixtures: %v", err)
+	}
+
+	fixtures := map[string]stateUpgradeFixture{}
+	for _, path := range paths {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("unexpected error reading %s: %v", path, err)
+		}
+		var fixture stateUpgradeFixture
+		if err := json.Unmarshal(b, &fixture); err != nil {
+			t.Fatalf("unexpected error parsing %s: %v", path, err)
+		}
+		fixtures[filepath.Base(path)] = fixture
+	}
+	return fixtures
+}
+
+func TestStateUpgradeFixtures(t *testing.T) {
+	ctx := context.Background()
+
+	p := Provider()
+	// Singleton upgraders read the cluster UUID, which is already known to this client
+	p.SetMeta(&Client{clusterUuid: testClusterUuid})
+	server := p.GRPCProvider()
+
+	schemas, err := server.GetProviderSchema(ctx, &tfprotov5.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatalf("unexpected error getting the schemas: %v", err)
+	}
+
+	for name, fixture := range readStateUpgradeFixtures(t) {
+		fixture := fixture
+		t.Run(name, func(t *testing.T) {
+			resourceSchema, ok := schemas.ResourceSchemas[fixture.ResourceType]
+			if !ok {
+				t.Fatalf("unexpected resource type %s", fixture.ResourceType)
+			}
+			objectType := resourceSchema.ValueType()
+
+			resp, err := server.UpgradeResourceState(ctx, &tfprotov5.UpgradeResourceStateRequest{
+				TypeName: fixture.ResourceType,
+				Version:  fixture.SchemaVersion,
+				RawState: &tfprotov5.RawState{JSON: fixture.State},
+			})
+			if err != nil {
+				t.Fatalf("unexpected error upgrading the state: %v", err)
+			}
+			for _, diagnostic := range resp.Diagnostics {
+				if diagnostic.Severity == tfprotov5.DiagnosticSeverityError {
+					t.Fatalf("unexpected error upgrading the state: %s: %s", diagnostic.Summary, diagnostic.Detail)
+				}
+			}
+
+			upgraded, err := resp.UpgradedState.Unmarshal(objectType)
+			if err != nil {
+				t.Fatalf("unexpected error decoding the upgraded state: %v", err)
+			}
+			expected, err := tftypes.ValueFromJSON(fixture.Upgraded, objectType)
+			if err != nil {
+				t.Fatalf("unexpected error decoding the expected state: %v", err)
+			}
+			if !upgraded.Equal(expected) {
+				t.Errorf("expected the state to be upgraded to\n%s\ngot\n%s", expected, upgraded)
+			}
+		})
+	}
+}
+
+// Every resource needs a fixture for each of its earlier schema versions, including the version 0
+// states of the singleton resources, and resources still at version 0 need one of that version. Each
+// fixture's state must be one that version could have written, so it can't hold later attributes.
+func TestStateUpgradeFixturesCoverSchemaVersions(t *testing.T) {
+	resources := Provider().ResourcesMap
+
+	covered := map[string]bool{}
+	for name, fixture := range readStateUpgradeFixtures(t) {
+		covered[fmt.Sprintf("%s_v%d", fixture.ResourceType, fixture.SchemaVersion)] = true
+
+		r, ok := resources[fixture.ResourceType]
+		if !ok {
+			t.Errorf("unexpected resource type %s in %s", fixture.ResourceType, name)
+			continue
+		}
+		stateType := cty.NilType
+		for _, upgrader := range r.StateUpgraders {
+			if int64(upgrader.Version) == fixture.SchemaVersion {
+				stateType = upgrader.Type
+			}
+		}
+		if stateType == cty.NilType && int64(r.SchemaVersion) == fixture.SchemaVersion {
+			stateType = r.CoreConfigSchema().ImpliedType()
+		}
+		if stateType == cty.NilType {
+			t.Errorf("unexpected schema version %d in %s", fixture.SchemaVersion, name)
+			continue
+		}
+		if _, err := ctyjson.Unmarshal(fixture.State, stateType); err != nil {
+			t.Errorf("expected the state in %s to match version %d of the schema: %v", name, fixture.SchemaVersion, err)
+		}
+	}
+
+	for resourceType, r := range resources {
+		versions := []int{0}
+		for _, upgrader := range r.StateUpgraders {
+			versions = append(versions, upgrader.Version)
+		}
+		for _, v := range versions {
+			version := fmt.Sprintf("%s_v%d", resourceType, v)
+			if !covered[version] {
+				t.Errorf("expected a fixture in testdata/state_upgrades for %s", version)
+			}
+		}
+	}
+}
+
+func TestUpgradeStringToInt(t *testing.T) {
+	rawState := map[string]interface{}{"digits": "2001", "empty": "", "number": json.Number("3")}
+	for _, key := range []string{"digits", "empty", "number", "missing"} {
+		if err := upgradeStringToInt(rawState, key); err != nil {
+			t.Errorf("unexpected error upgrading %s: %v", key, err)
+		}
+	}
+	if rawState["digits"] != json.Number("2001") {
+		t.Errorf("expected digits to become a number, got %#v", rawState["digits"])
+	}
+	if rawState["empty"] != nil {
+		t.Errorf("expected an empty string to become null, got %#v", rawState["empty"])
+	}
+	if rawState["number"] != json.Number("3") {
+		t.Errorf("expected a number to be kept, got %#v", rawState["number"])
+	}
+
+	if err := upgradeStringToInt(map[string]interface{}{"uid": "abc"}, "uid"); err == nil {
+		t.Errorf("expected an error upgrading a string which isn't a number")
+	}
+}
@@ -0,0 +1,275 @@
+package qumulo
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// Objects created by acceptance tests are named with this prefix, so that the sweepers can find the
+// ones left behind by failed runs. Run the sweepers against the cluster in QUMULO_HOST with
+//
+//	QUMULO_TEST_REAL_CLUSTER=1 go test ./qumulo -v -sweep=all
+const TestNamePrefix = "tf_acc_test"
+
+func TestMain(m *testing.M) {
+	resource.TestMain(m)
+}
+
+func init() {
+	resource.AddTestSweepers("qumulo_smb_share", &resource.Sweeper{
+		Name: "qumulo_smb_share",
+		F:    withSweeperClient(sweepSmbShares),
+	})
+	resource.AddTestSweepers("qumulo_nfs_export", &resource.Sweeper{
+		Name: "qumulo_nfs_export",
+		F:    withSweeperClient(sweepNfsExports),
+	})
+	resource.AddTestSweepers("qumulo_directory_quota", &resource.Sweeper{
+		Name: "qumulo_directory_quota",
+		F:    withSweeperClient(sweepDirectoryQuotas),
+	})
+	// Deleting a role removes its members
+	resource.AddTestSweepers("qumulo_role", &resource.Sweeper{
+		Name: "qumulo_role",
+		F:    withSweeperClient(sweepRoles),
+	})
+	// Shares refer to users as trustees, and roles to users as members
+	resource.AddTestSweepers("qumulo_local_user", &resource.Sweeper{
+		Name:         "qumulo_local_user",
+		F:            withSweeperClient(sweepUsers),
+		Dependencies: []string{"qumulo_smb_share", "qumulo_role"},
+	})
+	// Deleting a group removes its members, but not while it is a user's primary group
+	resource.AddTestSweepers("qumulo_local_group", &resource.Sweeper{
+		Name:         "qumulo_local_group",
+		F:            withSweeperClient(sweepGroups),
+		Dependencies: []string{"qumulo_local_user"},
+	})
+}
+
+// Clusters have no regions, so the region given to the sweepers is ignored
+func withSweeperClient(sweep func(ctx context.Context, c *Client) error) func(region string) error {
+	return func(region string) error {
+		if usingFakeCluster() {
+			return fmt.Errorf("sweepers clean up a real cluster; set %s and the QUMULO_* variables", RealClusterEnvVar)
+		}
+
+		host := os.Getenv("QUMULO_HOST")
+		port := os.Getenv("QUMULO_PORT")
+		username := os.Getenv("QUMULO_USERNAME")
+		password := os.Getenv("QUMULO_PASSWORD")
+
+		ctx := context.Background()
+		c, err := NewClient(ctx, &host, &port, &username, &password)
+		if err != nil {
+			return err
+		}
+
+		return sweep(ctx, c)
+	}
+}
+
+func isTestName(name string) bool {
+	return strings.HasPrefix(name, TestNamePrefix)
+}
+
+// Deletes each of the objects, carrying on past failures so that one stuck object doesn't keep the
+// others around
+func sweepObjects(ctx context.Context, c *Client, kind string, uris map[string]string) error {
+	var failed []string
+	for name, uri := range uris {
+		log.Printf("[INFO] Deleting %s %q", kind, name)
+
+		if _, err := DoRequest[struct{}, struct{}](ctx, c, DELETE, uri, nil); err != nil {
+			log.Printf("[ERROR] Deleting %s %q: %v", kind, name, err)
+			failed = append(failed, name)
+		}
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("failed to delete %d %s(s): %s", len(failed), kind, strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+func sweepSmbShares(ctx context.Context, c *Client) error {
+	shares, err := DoRequest[struct{}, []SmbShare](ctx, c, GET, SmbSharesEndpoint, nil)
+	if err != nil {
+		return err
+	}
+
+	uris := map[string]string{}
+	for _, share := range *shares {
+		if isTestName(share.ShareName) {
+			uris[share.ShareName] = SmbSharesEndpoint + share.Id
+		}
+	}
+
+	return sweepObjects(ctx, c, "SMB share", uris)
+}
+
+func sweepNfsExports(ctx context.Context, c *Client) error {
+	exports, err := DoRequest[struct{}, []NfsExport](ctx, c, GET, NfsExportsEndpoint, nil)
+	if err != nil {
+		return err
+	}
+
+	uris := map[string]string{}
+	for _, export := range *exports {
+		if isTestName(strings.TrimPrefix(export.ExportPath, "/")) {
+			uris[export.ExportPath] = NfsExportsEndpoint + export.Id
+		}
+	}
+
+	return sweepObjects(ctx, c, "NFS export", uris)
+}
+
+// Quotas are identified by the directory they limit, so the ones on directories created by the quota
+// tests are swept
+func sweepDirectoryQuotas(ctx context.Context, c *Client) error {
+	uris := map[string]string{}
+	nextUri := DirectoryQuotaStatusEndpoint
+	for nextUri != "" {
+		quotaPage, err := DoRequest[DirectoryQuotaEmptyBody, DirectoryQuotaStatusListResponse](ctx, c, GET, nextUri, nil)
+		if err != nil {
+			return err
+		}
+
+		for _, quota := range quotaPage.Quotas {
+			if isTestName(strings.TrimPrefix(quota.Path, "/")) {
+				uris[quota.Path] = DirectoryQuotaEndpoint + quota.Id
+			}
+		}
+		nextUri = quotaPage.Paging.Next
+	}
+
+	return sweepObjects(ctx, c, "directory quota", uris)
+}
+
+func sweepRoles(ctx context.Context, c *Client) error {
+	roles, err := DoRequest[struct{}, map[string]Role](ctx, c, GET, RolesEndpoint, nil)
+	if err != nil {
+		return err
+	}
+
+	uris := map[string]string{}
+	for name := range *roles {
+		if isTestName(name) {
+			uris[name] = RolesEndpoint + name
+		}
+	}
+
+	return sweepObjects(ctx, c, "role", uris)
+}
+
+func sweepUsers(ctx context.Context, c *Client) error {
+	users, err := DoRequest[struct{}, []UserBody](ctx, c, GET, UsersEndpoint, nil)
+	if err != nil {
+		return err
+	}
+
+	uris := map[string]string{}
+	for _, user := range *users {
+		if isTestName(user.Name) {
+			uris[user.Name] = UsersEndpoint + user.Id
+		}
+	}
+
+	return sweepObjects(ctx, c, "local user", uris)
+}
+
+func sweepGroups(ctx context.Context, c *Client) error {
+	groups, err := DoRequest[struct{}, []GroupResponse](ctx, c, GET, GroupsEndpoint, nil)
+	if err != nil {
+		return err
+	}
+
+	uris := map[string]string{}
+	for _, group := range *groups {
+		if isTestName(group.Name) {
+			uris[group.Name] = GroupsEndpoint + group.Id
+		}
+	}
+
+	return sweepObjects(ctx, c, "local group", uris)
+}
+
+func TestSweepers(t *testing.T) {
+	f := newFakeCluster()
+	defer f.Close()
+
+	ctx := context.Background()
+	u, _ := url.Parse(f.URL)
+	host, port, username, password := u.Hostname(), u.Port(), fakeClusterUsername, fakeClusterPassword
+	c, err := NewClient(ctx, &host, &port, &username, &password)
+	if err != nil {
+		t.Fatalf("unexpected error signing in to the fake cluster: %v", err)
+	}
+
+	create := func(uri string, body interface{}) map[string]interface{} {
+		created, err := DoRequest[interface{}, map[string]interface{}](ctx, c, POST, uri, &body)
+		if err != nil {
+			t.Fatalf("unexpected error creating %s: %v", uri, err)
+		}
+		return *created
+	}
+	create(UsersEndpoint, UserBody{Name: TestNamePrefix + "_user", PrimaryGroup: "513"})
+	create(UsersEndpoint, UserBody{Name: "admin2", PrimaryGroup: "513"})
+	create(GroupsEndpoint, CreateGroupRequest{Name: TestNamePrefix + "_group"})
+	create(GroupsEndpoint, CreateGroupRequest{Name: "Operators"})
+	create(RolesEndpoint, Role{Name: TestNamePrefix + "_role"})
+	create(RolesEndpoint, Role{Name: "Observers"})
+	create(SmbSharesEndpoint, SmbShare{ShareName: TestNamePrefix + "_share", FsPath: "/"})
+	create(SmbSharesEndpoint, SmbShare{ShareName: "Files", FsPath: "/"})
+	create(NfsExportsEndpoint, NfsExport{ExportPath: "/" + TestNamePrefix + "_export", FsPath: "/"})
+	create(NfsExportsEndpoint, NfsExport{ExportPath: "/home", FsPath: "/home"})
+	testDirectory := create(FilesEndpoint+fakeClusterRootId+"/entries/", CreateDirectoryRequest{Name: TestNamePrefix + "_quota", Action: "CREATE_DIRECTORY"})
+	homeDirectory := create(FilesEndpoint+fakeClusterRootId+"/entries/", CreateDirectoryRequest{Name: "home", Action: "CREATE_DIRECTORY"})
+	create(DirectoryQuotaEndpoint, DirectoryQuotaBody{Id: testDirectory["id"].(string), Limit: "1000"})
+	create(DirectoryQuotaEndpoint, DirectoryQuotaBody{Id: homeDirectory["id"].(string), Limit: "1000"})
+	create(DirectoryQuotaEndpoint, DirectoryQuotaBody{Id: fakeClusterRootId, Limit: "1000"})
+
+	for _, sweep := range []func(context.Context, *Client) error{
+		sweepSmbShares, sweepNfsExports, sweepDirectoryQuotas, sweepRoles, sweepUsers, sweepGroups,
+	} {
+		if err := sweep(ctx, c); err != nil {
+			t.Fatalf("unexpected error sweeping: %v", err)
+		}
+	}
+
+	var remaining []string
+	for path, object := range f.objects {
+		for collection := range fakeClusterCollections {
+			if !strings.HasPrefix(path, collection) {
+				continue
+			}
+			for _, k := range []string{"name", "share_name", "export_path"} {
+				if v, ok := object[k].(string); ok {
+					remaining = append(remaining, v)
+				}
+			}
+			if collection == DirectoryQuotaEndpoint {
+				remaining = append(remaining, "quota "+object["id"].(string))
+			}
+		}
+	}
+
+	// The quota on the root directory is kept, although the quota tests used to set one there
+	expected := []string{"admin2", "Operators", "Observers", "Files", "/home", "quota " + homeDirectory["id"].(string),
+		"quota " + fakeClusterRootId}
+	if len(remaining) != len(expected) {
+		t.Errorf("expected only %v to be left after sweeping, got %v", expected, remaining)
+	}
+	for _, v := range remaining {
+		if isTestName(strings.TrimPrefix(v, "/")) || v == "quota "+testDirectory["id"].(string) {
+			t.Errorf("expected %q to be swept", v)
+		}
+	}
+}
@@ -0,0 +1,46 @@
+// Code generated by tools/resourcegen from qumulo_api.json. DO NOT EDIT.
+
+package qumulo
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// How the cluster keeps its time
+type TimeConfigurationBody struct {
+	UseAdForPrimary bool     `json:"use_ad_for_primary"`
+	NtpServers      []string `json:"ntp_servers"`
+}
+
+func timeConfigurationBodySchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"use_ad_for_primary": {
+			Type:     schema.TypeBool,
+			Required: true,
+		},
+		"ntp_servers": {
+			Type:     schema.TypeList,
+			Required: true,
+			Elem: &schema.Schema{
+				Type:             schema.TypeString,
+				ValidateDiagFunc: validation.ToDiagFunc(validateHostOrIpAddress),
+			},
+		},
+	}
+}
+
+func expandTimeConfigurationBody(d *schema.ResourceData) TimeConfigurationBody {
+	timeConfigurationBody := TimeConfigurationBody{}
+	timeConfigurationBody.UseAdForPrimary = d.Get("use_ad_for_primary").(bool)
+	timeConfigurationBody.NtpServers = InterfaceSliceToStringSlice(d.Get("ntp_servers").([]interface{}))
+	return timeConfigurationBody
+}
+
+func flattenTimeConfigurationBody(d *schema.ResourceData, timeConfigurationBody *TimeConfigurationBody) diag.Diagnostics {
+	var errs ErrorCollection
+	errs.addMaybeError(d.Set("use_ad_for_primary", timeConfigurationBody.UseAdForPrimary))
+	errs.addMaybeError(d.Set("ntp_servers", timeConfigurationBody.NtpServers))
+	return errs.diags
+}
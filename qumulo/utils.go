@@ -1,11 +1,13 @@
 package qumulo
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"strconv"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -22,31 +24,73 @@ func (si *StringOrInt) UnmarshalJSON(data []byte) error {
 		return err
 	}
 
-	if intVal, err := i.(int); !err {
+	// JSON numbers are decoded as float64
+	switch v := i.(type) {
+	case float64:
 		si.isString = false
 		si.isInt = true
-		si.intVal = intVal
-	} else if stringVal, err := i.(string); !err {
+		si.intVal = int(v)
+	case string:
 		si.isString = true
 		si.isInt = false
-		si.stringVal = stringVal
-	} else {
+		si.stringVal = v
+	case nil:
+		// Identities the member doesn't have are null
+	default:
 		return fmt.Errorf("unknown input for StringOrInt: got value %q", i)
 	}
 
 	return nil
 }
 
-func SetStringOrIntValue(d *schema.ResourceData, field string, si StringOrInt) error {
+// Numbers are given as strings, and values missing from the response are null
+func (si StringOrInt) StringValue() types.String {
 	if si.isString {
-		d.Set(field, si.stringVal)
+		return types.StringValue(si.stringVal)
 	} else if si.isInt {
-		d.Set(field, fmt.Sprintf("%q", si.intVal))
-	} else {
-		return fmt.Errorf("unknown value/type for StringOrInt, not setting resource data: got %+v", si)
+		return types.StringValue(strconv.Itoa(si.intVal))
 	}
+	return types.StringNull()
+}
 
-	return nil
+// Sets an optional number which the API gives as a string, which is empty when unset
+func SetIntFromString(d *schema.ResourceData, field string, v string) error {
+	if v == "" {
+		return d.Set(field, nil)
+	}
+
+	i, err := strconv.Atoi(v)
+	if err != nil {
+		return fmt.Errorf("unexpected %s %q: %w", field, v, err)
+	}
+	return d.Set(field, i)
+}
+
+// The API takes optional numbers as strings, which are empty when unset
+func GetIntAsString(d *schema.ResourceData, field string) string {
+	if v, ok := d.GetOk(field); ok {
+		return strconv.Itoa(v.(int))
+	}
+	return ""
+}
+
+// Plans a computed attribute which holds the canonical number of another attribute, such as the bytes
+// of a size, so that its new value is known when the other attribute changes
+func planCanonicalNumber(field string, canonicalField string, canonical func(string) (int64, error)) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+		if !d.NewValueKnown(field) {
+			return d.SetNewComputed(canonicalField)
+		}
+
+		v, err := canonical(d.Get(field).(string))
+		if err != nil {
+			return fmt.Errorf("invalid %s: %w", field, err)
+		}
+		if d.Get(canonicalField).(int) == int(v) {
+			return nil
+		}
+		return d.SetNew(canonicalField, int(v))
+	}
 }
 
 func InterfaceSliceToStringSlice(interfaceSlice []interface{}) []string {
@@ -58,6 +102,16 @@ func InterfaceSliceToStringSlice(interfaceSlice []interface{}) []string {
 	return stringSlice
 }
 
+func StringSliceContains(stringSlice []string, str string) bool {
+	for _, element := range stringSlice {
+		if element == str {
+			return true
+		}
+	}
+
+	return false
+}
+
 func PrintTerraformListFromList(list []string) string {
 	return strings.ReplaceAll(fmt.Sprintf("%+q", list), "\" \"", "\", \"")
 }
@@ -66,6 +120,14 @@ func PrintTerraformListFromString(str string) string {
 	return strings.ReplaceAll(fmt.Sprintf("%+q", str), "\" \"", "\", \"")
 }
 
+// Numbers which the API gives as strings are empty when unset, which is null in Terraform
+func PrintTerraformNumberFromString(str string) string {
+	if str == "" {
+		return "null"
+	}
+	return str
+}
+
 func ParseLocalGroupMemberId(id string) ([]string, error) {
 	ids := strings.Split(id, ":")
 	if len(ids) != 2 {
@@ -0,0 +1,91 @@
+package qumulo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const DefaultWaiterMinInterval = 1 * time.Second
+const DefaultWaiterMaxInterval = 10 * time.Second
+const DefaultWaiterBackoff = 1.5
+
+// Polls a long-running cluster operation (an AD join, a replication sync, a tree delete, ...) until it
+// reaches a target state. There is no iteration limit: the operation is given up on when the context
+// expires, which for CRUD functions is the resource's configured timeout.
+type Waiter[R any] struct {
+	// Used in log messages and errors, e.g. "Active Directory join"
+	Operation string
+
+	// Fetches the current status of the operation and the state it is in
+	Refresh func(ctx context.Context) (*R, string, error)
+
+	// States the operation may pass through, and the states in which it is finished. A state that is
+	// neither is reported as an error.
+	Pending func(state string) bool
+	Target  func(state string) bool
+
+	// The interval between polls starts at MinInterval and is multiplied by Backoff after each poll,
+	// up to MaxInterval. Zero values fall back to the defaults above.
+	MinInterval time.Duration
+	MaxInterval time.Duration
+	Backoff     float64
+}
+
+func (w Waiter[R]) Wait(ctx context.Context) (*R, error) {
+	interval := w.MinInterval
+	if interval <= 0 {
+		interval = DefaultWaiterMinInterval
+	}
+	maxInterval := w.MaxInterval
+	if maxInterval <= 0 {
+		maxInterval = DefaultWaiterMaxInterval
+	}
+	backoff := w.Backoff
+	if backoff < 1 {
+		backoff = DefaultWaiterBackoff
+	}
+
+	start := time.Now()
+	for attempt := 1; ; attempt++ {
+		result, state, err := w.Refresh(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		if w.Target(state) {
+			tflog.Debug(ctx, fmt.Sprintf("%s finished", w.Operation), map[string]interface{}{
+				"state":   state,
+				"elapsed": time.Since(start).String(),
+			})
+			return result, nil
+		}
+
+		if !w.Pending(state) {
+			return result, fmt.Errorf("%s reached unexpected state %q", w.Operation, state)
+		}
+
+		tflog.Debug(ctx, fmt.Sprintf("Waiting for %s to complete", w.Operation), map[string]interface{}{
+			"state":   state,
+			"attempt": attempt,
+			"elapsed": time.Since(start).String(),
+			"next":    interval.String(),
+		})
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return result, fmt.Errorf("timed out waiting for %s after %s (last state %q): %w",
+				w.Operation, time.Since(start).Round(time.Second), state, ctx.Err())
+		case <-timer.C:
+		}
+
+		interval = time.Duration(float64(interval) * backoff)
+		if interval > maxInterval {
+			interval = maxInterval
+		}
+	}
+}
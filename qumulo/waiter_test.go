@@ -0,0 +1,57 @@
+package qumulo
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func testWaiter(states []string) (Waiter[int], *int) {
+	polls := 0
+	return Waiter[int]{
+		Operation: "test operation",
+		Refresh: func(ctx context.Context) (*int, string, error) {
+			state := states[polls]
+			if polls < len(states)-1 {
+				polls++
+			}
+			return &polls, state, nil
+		},
+		Pending:     func(state string) bool { return state == "RUNNING" },
+		Target:      func(state string) bool { return state == "DONE" },
+		MinInterval: time.Millisecond,
+		MaxInterval: 2 * time.Millisecond,
+	}, &polls
+}
+
+func TestWaiterReachesTarget(t *testing.T) {
+	w, polls := testWaiter([]string{"RUNNING", "RUNNING", "DONE"})
+
+	if _, err := w.Wait(context.Background()); err != nil {
+		t.Fatalf("unexpected error waiting: %v", err)
+	}
+	if *polls != 2 {
+		t.Errorf("expected the operation to be polled until it finished, got %d polls", *polls)
+	}
+}
+
+func TestWaiterUnexpectedState(t *testing.T) {
+	w, _ := testWaiter([]string{"RUNNING", "FAILED"})
+
+	if _, err := w.Wait(context.Background()); err == nil {
+		t.Errorf("expected an error for a state which is neither pending nor a target")
+	}
+}
+
+func TestWaiterContextDeadline(t *testing.T) {
+	w, _ := testWaiter([]string{"RUNNING"})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	_, err := w.Wait(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected the wait to stop at the context deadline, got %v", err)
+	}
+}
@@ -0,0 +1,493 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+type generator struct {
+	spec     *Spec
+	specPath string
+
+	// The resource each definition is generated with; a nested definition shared by several resources
+	// is generated with the first of them
+	owners map[string]string
+	// The values of each enum, and the resource they are generated with
+	enums      map[string][]string
+	enumOwners map[string]string
+}
+
+// Generates a Go file for each resource in the spec, keyed by file name
+func generateFiles(specPath string) (map[string][]byte, error) {
+	spec, err := loadSpec(specPath)
+	if err != nil {
+		return nil, err
+	}
+
+	g := &generator{
+		spec:       spec,
+		specPath:   specPath,
+		owners:     map[string]string{},
+		enums:      map[string][]string{},
+		enumOwners: map[string]string{},
+	}
+
+	resources := map[string]string{}
+	var resourceNames []string
+	for name, definition := range spec.Definitions {
+		if definition.TerraformResource != "" {
+			resources[definition.TerraformResource] = name
+			resourceNames = append(resourceNames, definition.TerraformResource)
+		}
+	}
+	sort.Strings(resourceNames)
+
+	definitions := map[string][]string{}
+	for _, resource := range resourceNames {
+		definitions[resource] = g.ownDefinitions(resource, resources[resource], nil)
+		for _, name := range definitions[resource] {
+			if err := g.collectEnums(resource, name); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	files := map[string][]byte{}
+	for _, resource := range resourceNames {
+		src, err := g.generateFile(resource, definitions[resource])
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", resource, err)
+		}
+
+		formatted, err := format.Source(src)
+		if err != nil {
+			return nil, fmt.Errorf("%s: formatting generated code: %w\n%s", resource, err, src)
+		}
+		files[strings.TrimPrefix(resource, "qumulo_")+"_generated.go"] = formatted
+	}
+
+	return files, nil
+}
+
+// Returns the definition and the nested definitions it refers to which aren't generated with another
+// resource, in the order they are first referred to
+func (g *generator) ownDefinitions(resource, name string, names []string) []string {
+	if _, ok := g.owners[name]; ok {
+		return names
+	}
+	g.owners[name] = resource
+	names = append(names, name)
+
+	definition := g.spec.Definitions[name]
+	for _, propertyName := range definition.Properties.Names {
+		p := definition.Properties.ByName[propertyName]
+		if p.Items != nil {
+			p = p.Items
+		}
+		if p.Ref != "" {
+			names = g.ownDefinitions(resource, p.RefName(), names)
+		}
+	}
+
+	return names
+}
+
+func (g *generator) collectEnums(resource, name string) error {
+	definition := g.spec.Definitions[name]
+	for _, propertyName := range definition.Properties.Names {
+		p := definition.Properties.ByName[propertyName]
+		if p.Items != nil {
+			p = p.Items
+		}
+		if len(p.Enum) == 0 {
+			continue
+		}
+		if p.Type != "string" {
+			return fmt.Errorf("%s.%s: only string enums are supported", name, propertyName)
+		}
+
+		enumName := g.enumName(name, propertyName, p)
+		if values, ok := g.enums[enumName]; ok {
+			if strings.Join(values, ",") != strings.Join(p.Enum, ",") {
+				return fmt.Errorf("%s.%s: enum %s is given different values elsewhere", name, propertyName, enumName)
+			}
+			continue
+		}
+		g.enums[enumName] = p.Enum
+		g.enumOwners[enumName] = resource
+	}
+	return nil
+}
+
+func (g *generator) enumName(definitionName, propertyName string, p *Property) string {
+	if p.GoEnumName != "" {
+		return p.GoEnumName
+	}
+	return definitionName + goName(propertyName, nil) + "Values"
+}
+
+func (g *generator) generateFile(resource string, definitions []string) ([]byte, error) {
+	var body bytes.Buffer
+
+	var enumNames []string
+	for name, owner := range g.enumOwners {
+		if owner == resource {
+			enumNames = append(enumNames, name)
+		}
+	}
+	sort.Strings(enumNames)
+	for _, name := range enumNames {
+		var values []string
+		for _, value := range g.enums[name] {
+			values = append(values, strconv.Quote(value))
+		}
+		fmt.Fprintf(&body, "var %s = []string{%s}\n\n", name, strings.Join(values, ", "))
+	}
+
+	for _, name := range definitions {
+		if err := g.writeDefinition(&body, name); err != nil {
+			return nil, err
+		}
+	}
+
+	var src bytes.Buffer
+	fmt.Fprintf(&src, "// Code generated by tools/resourcegen from %s. DO NOT EDIT.\n\n", filepath.Base(g.specPath))
+	fmt.Fprintf(&src, "package qumulo\n\nimport (\n")
+	if bytes.Contains(body.Bytes(), []byte("diag.")) {
+		fmt.Fprintf(&src, "%q\n", "github.com/hashicorp/terraform-plugin-sdk/v2/diag")
+	}
+	fmt.Fprintf(&src, "%q\n", "github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema")
+	if bytes.Contains(body.Bytes(), []byte("validation.")) {
+		fmt.Fprintf(&src, "%q\n", "github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation")
+	}
+	fmt.Fprintf(&src, ")\n\n")
+	src.Write(body.Bytes())
+
+	return src.Bytes(), nil
+}
+
+func (g *generator) writeDefinition(w *bytes.Buffer, name string) error {
+	definition := g.spec.Definitions[name]
+	isResource := definition.TerraformResource != ""
+	variable := lowerFirst(name)
+
+	// The struct sent to and received from the API
+	if definition.Description != "" {
+		fmt.Fprintf(w, "// %s\n", definition.Description)
+	}
+	fmt.Fprintf(w, "type %s struct {\n", name)
+	for _, propertyName := range definition.Properties.Names {
+		p := definition.Properties.ByName[propertyName]
+		goType, err := g.goType(p)
+		if err != nil {
+			return fmt.Errorf("%s.%s: %w", name, propertyName, err)
+		}
+
+		if p.Description != "" {
+			fmt.Fprintf(w, "// %s\n", p.Description)
+		}
+		tag := propertyName
+		if p.GoOmitempty {
+			tag += ",omitempty"
+		}
+		fmt.Fprintf(w, "%s %s `json:%q`\n", goName(propertyName, p), goType, tag)
+	}
+	fmt.Fprintf(w, "}\n\n")
+
+	// The attributes of the resource or nested block
+	fmt.Fprintf(w, "func %sSchema() map[string]*schema.Schema {\n", variable)
+	fmt.Fprintf(w, "return map[string]*schema.Schema{\n")
+	for _, propertyName := range definition.Properties.Names {
+		p := definition.Properties.ByName[propertyName]
+		if p.TerraformExclude {
+			continue
+		}
+		if err := g.writeAttribute(w, name, propertyName, p, definition.IsRequired(propertyName)); err != nil {
+			return fmt.Errorf("%s.%s: %w", name, propertyName, err)
+		}
+	}
+	fmt.Fprintf(w, "}\n}\n\n")
+
+	if isResource {
+		g.writeResourceExpand(w, name, definition)
+		g.writeResourceFlatten(w, name, definition)
+	} else {
+		g.writeNestedExpand(w, name, definition)
+		g.writeNestedFlatten(w, name, definition)
+	}
+
+	return nil
+}
+
+func (g *generator) goType(p *Property) (string, error) {
+	if p.Ref != "" {
+		return p.RefName(), nil
+	}
+
+	switch p.Type {
+	case "string":
+		return "string", nil
+	case "integer":
+		return "int", nil
+	case "boolean":
+		return "bool", nil
+	case "number":
+		return "float64", nil
+	case "array":
+		if p.Items == nil {
+			return "", fmt.Errorf("arrays must have items")
+		}
+		if p.Items.Ref == "" && p.Items.Type != "string" {
+			return "", fmt.Errorf("only arrays of strings or objects are supported")
+		}
+		items, err := g.goType(p.Items)
+		return "[]" + items, err
+	case "object":
+		if p.AdditionalProperties == nil || p.AdditionalProperties.Type != "string" {
+			return "", fmt.Errorf("objects must be given as definitions, or be maps of strings")
+		}
+		return "map[string]interface{}", nil
+	}
+
+	return "", fmt.Errorf("unknown type %q", p.Type)
+}
+
+func schemaType(p *Property) string {
+	if p.Ref != "" {
+		return "schema.TypeList"
+	}
+
+	return map[string]string{
+		"string":  "schema.TypeString",
+		"integer": "schema.TypeInt",
+		"boolean": "schema.TypeBool",
+		"number":  "schema.TypeFloat",
+		"array":   "schema.TypeList",
+		"object":  "schema.TypeMap",
+	}[p.Type]
+}
+
+func (g *generator) writeAttribute(w *bytes.Buffer, definitionName, name string, p *Property, required bool) error {
+	fmt.Fprintf(w, "%q: {\n", name)
+	fmt.Fprintf(w, "Type: %s,\n", schemaType(p))
+
+	switch {
+	case p.ReadOnly:
+		fmt.Fprintf(w, "Computed: true,\n")
+	case required:
+		fmt.Fprintf(w, "Required: true,\n")
+	default:
+		fmt.Fprintf(w, "Optional: true,\n")
+		if p.TerraformComputed {
+			fmt.Fprintf(w, "Computed: true,\n")
+		}
+	}
+	if p.TerraformForceNew {
+		fmt.Fprintf(w, "ForceNew: true,\n")
+	}
+
+	// A single nested object is a block which may be given once
+	minItems, maxItems := p.MinItems, p.MaxItems
+	if p.Ref != "" {
+		maxItems = 1
+		if required {
+			minItems = 1
+		}
+	}
+	if maxItems > 0 {
+		fmt.Fprintf(w, "MaxItems: %d,\n", maxItems)
+	}
+	if minItems > 0 {
+		fmt.Fprintf(w, "MinItems: %d,\n", minItems)
+	}
+
+	switch {
+	case p.Ref != "":
+		fmt.Fprintf(w, "Elem: &schema.Resource{\nSchema: %sSchema(),\n},\n", lowerFirst(p.RefName()))
+	case p.Items != nil && p.Items.Ref != "":
+		fmt.Fprintf(w, "Elem: &schema.Resource{\nSchema: %sSchema(),\n},\n", lowerFirst(p.Items.RefName()))
+	case p.Items != nil:
+		fmt.Fprintf(w, "Elem: &schema.Schema{\nType: %s,\n", schemaType(p.Items))
+		if err := g.writeValidation(w, definitionName, name, p.Items); err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "},\n")
+	case p.AdditionalProperties != nil:
+		fmt.Fprintf(w, "Elem: &schema.Schema{\nType: %s,\n},\n", schemaType(p.AdditionalProperties))
+	default:
+		if err := g.writeValidation(w, definitionName, name, p); err != nil {
+			return err
+		}
+	}
+
+	fmt.Fprintf(w, "},\n")
+	return nil
+}
+
+func (g *generator) writeValidation(w *bytes.Buffer, definitionName, name string, p *Property) error {
+	if len(p.Enum) > 0 && p.TerraformValidate != "" {
+		return fmt.Errorf("an enum can't have a validation function as well")
+	}
+
+	if len(p.Enum) > 0 {
+		fmt.Fprintf(w, "ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice(%s, false)),\n",
+			g.enumName(definitionName, name, p))
+	}
+	if p.TerraformValidate != "" {
+		fmt.Fprintf(w, "ValidateDiagFunc: validation.ToDiagFunc(%s),\n", p.TerraformValidate)
+	}
+	return nil
+}
+
+// Resource bodies are expanded from the resource data, and flattened into it
+func (g *generator) writeResourceExpand(w *bytes.Buffer, name string, definition *Definition) {
+	variable := lowerFirst(name)
+
+	fmt.Fprintf(w, "func expand%s(d *schema.ResourceData) %s {\n", name, name)
+	fmt.Fprintf(w, "%s := %s{}\n", variable, name)
+	for _, propertyName := range definition.Properties.Names {
+		p := definition.Properties.ByName[propertyName]
+		if p.TerraformExclude {
+			continue
+		}
+		field := variable + "." + goName(propertyName, p)
+		get := fmt.Sprintf("d.Get(%q)", propertyName)
+
+		switch {
+		case p.Ref != "":
+			fmt.Fprintf(w, "if v := expand%sList(%s.([]interface{})); len(v) > 0 {\n%s = v[0]\n}\n", p.RefName(), get, field)
+		case p.Items != nil && p.Items.Ref != "":
+			fmt.Fprintf(w, "%s = expand%sList(%s.([]interface{}))\n", field, p.Items.RefName(), get)
+		case p.Items != nil:
+			fmt.Fprintf(w, "%s = InterfaceSliceToStringSlice(%s.([]interface{}))\n", field, get)
+		default:
+			goType, _ := g.goType(p)
+			fmt.Fprintf(w, "%s = %s.(%s)\n", field, get, goType)
+		}
+	}
+	fmt.Fprintf(w, "return %s\n}\n\n", variable)
+}
+
+func (g *generator) writeResourceFlatten(w *bytes.Buffer, name string, definition *Definition) {
+	variable := lowerFirst(name)
+
+	fmt.Fprintf(w, "func flatten%s(d *schema.ResourceData, %s *%s) diag.Diagnostics {\n", name, variable, name)
+	fmt.Fprintf(w, "var errs ErrorCollection\n")
+	for _, propertyName := range definition.Properties.Names {
+		p := definition.Properties.ByName[propertyName]
+		if p.TerraformExclude {
+			continue
+		}
+		field := variable + "." + goName(propertyName, p)
+
+		value := field
+		switch {
+		case p.Ref != "":
+			value = fmt.Sprintf("flatten%sList([]%s{%s})", p.RefName(), p.RefName(), field)
+		case p.Items != nil && p.Items.Ref != "":
+			value = fmt.Sprintf("flatten%sList(%s)", p.Items.RefName(), field)
+		}
+		fmt.Fprintf(w, "errs.addMaybeError(d.Set(%q, %s))\n", propertyName, value)
+	}
+	fmt.Fprintf(w, "return errs.diags\n}\n\n")
+}
+
+// Nested objects are expanded from the maps the elements of blocks are given as, and flattened into them
+func (g *generator) writeNestedExpand(w *bytes.Buffer, name string, definition *Definition) {
+	variable := lowerFirst(name)
+
+	fmt.Fprintf(w, "func expand%sList(tfList []interface{}) []%s {\n", name, name)
+	fmt.Fprintf(w, "var %sList []%s\n", variable, name)
+	fmt.Fprintf(w, "for _, tfElement := range tfList {\n")
+	fmt.Fprintf(w, "if tfMap, ok := tfElement.(map[string]interface{}); ok {\n")
+	fmt.Fprintf(w, "%sList = append(%sList, expand%s(tfMap))\n", variable, variable, name)
+	fmt.Fprintf(w, "} else {\n%sList = append(%sList, %s{})\n}\n", variable, variable, name)
+	fmt.Fprintf(w, "}\nreturn %sList\n}\n\n", variable)
+
+	fmt.Fprintf(w, "func expand%s(tfMap map[string]interface{}) %s {\n", name, name)
+	fmt.Fprintf(w, "%s := %s{}\n", variable, name)
+	for _, propertyName := range definition.Properties.Names {
+		p := definition.Properties.ByName[propertyName]
+		if p.TerraformExclude {
+			continue
+		}
+		field := variable + "." + goName(propertyName, p)
+
+		switch {
+		case p.Ref != "":
+			fmt.Fprintf(w, "if v, ok := tfMap[%q].([]interface{}); ok {\n", propertyName)
+			fmt.Fprintf(w, "if elements := expand%sList(v); len(elements) > 0 {\n%s = elements[0]\n}\n}\n", p.RefName(), field)
+		case p.Items != nil && p.Items.Ref != "":
+			fmt.Fprintf(w, "if v, ok := tfMap[%q].([]interface{}); ok {\n%s = expand%sList(v)\n}\n", propertyName, field, p.Items.RefName())
+		case p.Items != nil:
+			fmt.Fprintf(w, "if v, ok := tfMap[%q].([]interface{}); ok {\n%s = InterfaceSliceToStringSlice(v)\n}\n", propertyName, field)
+		default:
+			goType, _ := g.goType(p)
+			fmt.Fprintf(w, "if v, ok := tfMap[%q].(%s); ok {\n%s = v\n}\n", propertyName, goType, field)
+		}
+	}
+	fmt.Fprintf(w, "return %s\n}\n\n", variable)
+}
+
+func (g *generator) writeNestedFlatten(w *bytes.Buffer, name string, definition *Definition) {
+	variable := lowerFirst(name)
+
+	fmt.Fprintf(w, "func flatten%sList(%sList []%s) []interface{} {\n", name, variable, name)
+	fmt.Fprintf(w, "var tfList []interface{}\n")
+	fmt.Fprintf(w, "for _, %s := range %sList {\n", variable, variable)
+	fmt.Fprintf(w, "tfList = append(tfList, flatten%s(%s))\n", name, variable)
+	fmt.Fprintf(w, "}\nreturn tfList\n}\n\n")
+
+	fmt.Fprintf(w, "func flatten%s(%s %s) map[string]interface{} {\n", name, variable, name)
+	fmt.Fprintf(w, "tfMap := map[string]interface{}{}\n")
+	for _, propertyName := range definition.Properties.Names {
+		p := definition.Properties.ByName[propertyName]
+		if p.TerraformExclude {
+			continue
+		}
+		field := variable + "." + goName(propertyName, p)
+
+		switch {
+		case p.Ref != "":
+			fmt.Fprintf(w, "tfMap[%q] = flatten%sList([]%s{%s})\n", propertyName, p.RefName(), p.RefName(), field)
+		case p.Items != nil && p.Items.Ref != "":
+			fmt.Fprintf(w, "tfMap[%q] = flatten%sList(%s)\n", propertyName, p.Items.RefName(), field)
+		case p.Items != nil:
+			// Empty lists are left unset, which Terraform treats the same as an empty list
+			fmt.Fprintf(w, "if len(%s) != 0 {\ntfMap[%q] = %s\n}\n", field, propertyName, field)
+		default:
+			fmt.Fprintf(w, "tfMap[%q] = %s\n", propertyName, field)
+		}
+	}
+	fmt.Fprintf(w, "return tfMap\n}\n\n")
+}
+
+// Turns a snake_case API field name into a Go identifier, unless the property gives one
+func goName(name string, p *Property) string {
+	if p != nil && p.GoName != "" {
+		return p.GoName
+	}
+
+	var b strings.Builder
+	for _, word := range strings.Split(name, "_") {
+		if word == "" {
+			continue
+		}
+		runes := []rune(word)
+		runes[0] = unicode.ToUpper(runes[0])
+		b.WriteString(string(runes))
+	}
+	return b.String()
+}
+
+func lowerFirst(name string) string {
+	runes := []rune(name)
+	runes[0] = unicode.ToLower(runes[0])
+	return string(runes)
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGeneratedFilesAreCurrent(t *testing.T) {
+	files, err := generateFiles("qumulo_api.json")
+	if err != nil {
+		t.Fatalf("unexpected error generating: %v", err)
+	}
+
+	for name, generated := range files {
+		checkedIn, err := os.ReadFile(filepath.Join("..", "..", "qumulo", name))
+		if err != nil {
+			t.Errorf("%s isn't checked in; run go generate ./...", name)
+			continue
+		}
+		if string(checkedIn) != string(generated) {
+			t.Errorf("qumulo/%s is out of date with qumulo_api.json; run go generate ./...", name)
+		}
+	}
+}
+
+func TestGoName(t *testing.T) {
+	cases := map[string]string{
+		"id":                          "Id",
+		"fs_path":                     "FsPath",
+		"fields_to_present_as_32_bit": "FieldsToPresentAs32Bit",
+	}
+	for name, expected := range cases {
+		if got := goName(name, nil); got != expected {
+			t.Errorf("expected the Go name of %q to be %q, got %q", name, expected, got)
+		}
+	}
+
+	if got := goName("access_based_enumeration_enabled", &Property{GoName: "AccessBasedEnumEnabled"}); got != "AccessBasedEnumEnabled" {
+		t.Errorf("expected x-go-name to be used, got %q", got)
+	}
+}
+
+func TestLoadSpecErrors(t *testing.T) {
+	cases := map[string]string{
+		"unknown ref": `{"definitions": {"A": {"type": "object", "properties": {"b": {"$ref": "#/definitions/B"}}}}}`,
+		"required":    `{"definitions": {"A": {"type": "object", "properties": {"b": {"type": "string"}}, "required": ["c"]}}}`,
+		"duplicate":   `{"definitions": {"A": {"type": "object", "properties": {"b": {"type": "string"}, "b": {"type": "string"}}}}}`,
+	}
+	for name, spec := range cases {
+		path := filepath.Join(t.TempDir(), "spec.json")
+		if err := os.WriteFile(path, []byte(spec), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := loadSpec(path); err == nil {
+			t.Errorf("%s: expected the spec to be rejected", name)
+		}
+	}
+
+	path := filepath.Join(t.TempDir(), "spec.json")
+	os.WriteFile(path, []byte(`{"definitions": {"A": {"type": "object", "x-terraform-resource": "qumulo_a",
+		"properties": {"b": {"type": "array", "items": {"type": "integer"}}}}}}`), 0644)
+	if _, err := generateFiles(path); err == nil || !strings.Contains(err.Error(), "only arrays of strings") {
+		t.Errorf("expected arrays of integers to be rejected, got %v", err)
+	}
+}
+
+func TestForceNew(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "spec.json")
+	os.WriteFile(path, []byte(`{"definitions": {"A": {"type": "object", "x-terraform-resource": "qumulo_a",
+		"properties": {"b": {"type": "string", "x-terraform-force-new": true}, "c": {"type": "string"}}}}}`), 0644)
+	files, err := generateFiles(path)
+	if err != nil {
+		t.Fatalf("unexpected error generating: %v", err)
+	}
+
+	generated := string(files["a_generated.go"])
+	if strings.Count(generated, "ForceNew: true") != 1 || !strings.Contains(generated, "Optional: true,\n\t\t\tForceNew: true,") {
+		t.Errorf("expected only b to force a new resource:\n%s", generated)
+	}
+}
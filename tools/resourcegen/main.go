@@ -0,0 +1,37 @@
+// Command resourcegen generates the API structs, schemas and expand and flatten helpers of the
+// provider's resources from qumulo_api.json, a snapshot of the Qumulo REST API schemas. Run it with
+// `go generate ./...` after changing the snapshot.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+func main() {
+	specPath := flag.String("spec", "qumulo_api.json", "the API schema snapshot")
+	outputDir := flag.String("out", ".", "the directory to write the generated files to")
+	flag.Parse()
+
+	files, err := generateFiles(*specPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "resourcegen: %v\n", err)
+		os.Exit(1)
+	}
+
+	var names []string
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if err := os.WriteFile(filepath.Join(*outputDir, name), files[name], 0644); err != nil {
+			fmt.Fprintf(os.Stderr, "resourcegen: %v\n", err)
+			os.Exit(1)
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// A snapshot of the Qumulo REST API schemas, in JSON schema with extensions for the choices the API
+// doesn't make, such as which fields the cluster computes
+type Spec struct {
+	Description string                 `json:"description"`
+	Definitions map[string]*Definition `json:"definitions"`
+}
+
+type Definition struct {
+	Type        string     `json:"type"`
+	Description string     `json:"description"`
+	Properties  Properties `json:"properties"`
+	Required    []string   `json:"required"`
+
+	// The resource type managed with this definition, if it's the body of a resource rather than a
+	// nested object
+	TerraformResource string `json:"x-terraform-resource"`
+}
+
+func (d *Definition) IsRequired(name string) bool {
+	for _, required := range d.Required {
+		if required == name {
+			return true
+		}
+	}
+	return false
+}
+
+type Property struct {
+	Type                 string    `json:"type"`
+	Description          string    `json:"description"`
+	Ref                  string    `json:"$ref"`
+	Items                *Property `json:"items"`
+	AdditionalProperties *Property `json:"additionalProperties"`
+	Enum                 []string  `json:"enum"`
+	ReadOnly             bool      `json:"readOnly"`
+	MinItems             int       `json:"minItems"`
+	MaxItems             int       `json:"maxItems"`
+
+	TerraformComputed bool   `json:"x-terraform-computed"`
+	TerraformForceNew bool   `json:"x-terraform-force-new"`
+	TerraformExclude  bool   `json:"x-terraform-exclude"`
+	TerraformValidate string `json:"x-terraform-validate"`
+	GoName            string `json:"x-go-name"`
+	GoEnumName        string `json:"x-go-enum-name"`
+	GoOmitempty       bool   `json:"x-go-omitempty"`
+}
+
+// The name of the definition a $ref refers to
+func (p *Property) RefName() string {
+	return strings.TrimPrefix(p.Ref, "#/definitions/")
+}
+
+// Properties keep the order they are given in, which is the order of the generated struct fields and
+// schema attributes
+type Properties struct {
+	Names  []string
+	ByName map[string]*Property
+}
+
+func (p *Properties) UnmarshalJSON(b []byte) error {
+	decoder := json.NewDecoder(bytes.NewReader(b))
+
+	if t, err := decoder.Token(); err != nil || t != json.Delim('{') {
+		return fmt.Errorf("properties must be an object")
+	}
+
+	p.ByName = map[string]*Property{}
+	for decoder.More() {
+		t, err := decoder.Token()
+		if err != nil {
+			return err
+		}
+		name := t.(string)
+
+		var property Property
+		if err := decoder.Decode(&property); err != nil {
+			return fmt.Errorf("property %q: %w", name, err)
+		}
+		if _, ok := p.ByName[name]; ok {
+			return fmt.Errorf("property %q is given twice", name)
+		}
+
+		p.Names = append(p.Names, name)
+		p.ByName[name] = &property
+	}
+
+	return nil
+}
+
+func loadSpec(path string) (*Spec, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var spec Spec
+	if err := json.Unmarshal(b, &spec); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+
+	for name, definition := range spec.Definitions {
+		if definition.Type != "object" {
+			return nil, fmt.Errorf("definition %s must be an object", name)
+		}
+		for _, required := range definition.Required {
+			if _, ok := definition.Properties.ByName[required]; !ok {
+				return nil, fmt.Errorf("definition %s requires %q, which isn't one of its properties", name, required)
+			}
+		}
+		for _, propertyName := range definition.Properties.Names {
+			if err := spec.checkRefs(definition.Properties.ByName[propertyName]); err != nil {
+				return nil, fmt.Errorf("%s.%s: %w", name, propertyName, err)
+			}
+		}
+	}
+
+	return &spec, nil
+}
+
+func (s *Spec) checkRefs(p *Property) error {
+	if p.Ref != "" {
+		if _, ok := s.Definitions[p.RefName()]; !ok {
+			return fmt.Errorf("unknown definition %s", p.Ref)
+		}
+	}
+	if p.Items != nil {
+		return s.checkRefs(p.Items)
+	}
+	return nil
+}